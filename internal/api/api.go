@@ -0,0 +1,81 @@
+// Package api defines the JSON representation of the outcome of a crossword search, shared by the command line and
+// the server.
+package api
+
+import (
+	"crogo/pkg/grid"
+	"strings"
+	"time"
+)
+
+// Status is the reason why the iteration over the solutions stopped.
+type Status string
+
+const (
+	// StatusExhausted means that all the solutions have been found.
+	StatusExhausted Status = "exhausted"
+	// StatusCountReached means that the desired number of solutions has been found.
+	StatusCountReached Status = "count-reached"
+	// StatusTimedOut means that the search has been interrupted because of the timeout.
+	StatusTimedOut Status = "timed-out"
+	// StatusStopped means that the search has been stopped on request.
+	StatusStopped Status = "stopped"
+)
+
+// Solution is the JSON representation of a solution.
+type Solution struct {
+	Index     int      `json:"index"`
+	Solver    string   `json:"solver"`
+	ElapsedMs int64    `json:"elapsedMs"`
+	Rows      []string `json:"rows"`
+	Entries   []Entry  `json:"entries"`
+}
+
+// Entry is the JSON representation of a word in a numbered slot.
+type Entry struct {
+	Number    int    `json:"number"`
+	Direction string `json:"direction"`
+	Row       int    `json:"row"`
+	Column    int    `json:"column"`
+	Word      string `json:"word"`
+	Clue      string `json:"clue,omitempty"`
+}
+
+// End is the JSON representation of the outcome of the search.
+type End struct {
+	Status    Status `json:"status"`
+	Count     int    `json:"count"`
+	ElapsedMs int64  `json:"elapsedMs"`
+}
+
+// Result is the JSON representation of a complete search, i.e. all its solutions and its outcome.
+type Result struct {
+	Solutions []Solution `json:"solutions"`
+	End
+}
+
+// SolutionFrom converts the given solution of the given grid, found by the given solver after the given elapsed time,
+// to its JSON representation, with the clues given by the given clue function if not nil.
+func SolutionFrom(g *grid.Grid, clueOf func(grid.Entry) string, solution [][]rune, index int, solverName string,
+	elapsed time.Duration) Solution {
+	rows := make([]string, len(solution))
+	for i, row := range solution {
+		rows[i] = string(row)
+	}
+	gridEntries := g.Entries(solution)
+	entries := make([]Entry, len(gridEntries))
+	for i, entry := range gridEntries {
+		start := entry.Start()
+		entries[i] = Entry{
+			Number:    entry.Number(),
+			Direction: strings.ToLower(entry.Direction().String()),
+			Row:       start.Row(),
+			Column:    start.Column(),
+			Word:      entry.Word(),
+		}
+		if clueOf != nil {
+			entries[i].Clue = clueOf(entry)
+		}
+	}
+	return Solution{index, solverName, elapsed.Milliseconds(), rows, entries}
+}
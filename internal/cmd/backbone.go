@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"crogo/pkg/solver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// backboneSolverName is the name of the solver computing the backbone.
+var backboneSolverName string
+
+// backboneTimeout is the maximal duration of the computation, 0 meaning no limit.
+var backboneTimeout time.Duration
+
+// backboneCmd represents the backbone command.
+var backboneCmd = &cobra.Command{
+	Use:   "backbone <GRID>",
+	Short: "Print the letters forced in every fill of a crossword grid",
+	Long: `Print the letters which are the same in every fill of a crossword grid, the other cells being empty.
+
+The grid is printed in the text input format, so that it can be given back to crogo.
+
+Examples:
+
+$ crogo backbone "QI#,...,#.."
+QI#,I..,#..
+`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runBackbone,
+	SilenceUsage: true,
+}
+
+func init() {
+	backboneCmd.Flags().StringVarP(&backboneSolverName, "solver", "s", "logicng", "the desired solver backend. Possible values are: logicng, gini")
+	backboneCmd.Flags().DurationVarP(&backboneTimeout, "timeout", "t", 0, "the maximal duration of the computation, e.g. 30s; 0 means no limit")
+	rootCmd.AddCommand(backboneCmd)
+}
+
+func runBackbone(_ *cobra.Command, args []string) error {
+	crossword, errCrossword := crosswordFrom(args[0])
+	s, errSolver := solver.NewSolver(backboneSolverName)
+	if errCrossword != nil || errSolver != nil {
+		return errors.Join(errCrossword, errSolver)
+	}
+	ctx := context.Background()
+	if backboneTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, backboneTimeout)
+		defer cancel()
+	}
+	backbone, err := crossword.BackboneWith(ctx, s)
+	if err != nil {
+		return err
+	}
+	if backbone == nil {
+		return errNoSolution
+	}
+	rows := make([]string, len(backbone))
+	for i, row := range backbone {
+		rows[i] = string(row)
+	}
+	fmt.Println(strings.Join(rows, ","))
+	return nil
+}
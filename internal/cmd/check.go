@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"crogo/pkg/crogo"
+	"crogo/pkg/dictionaries"
+	"crogo/pkg/grid"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// checkFormat is the desired output format of the check command.
+var checkFormat string
+
+// checkCmd represents the check command.
+var checkCmd = &cobra.Command{
+	Use:   "check <GRID>",
+	Short: "Validate a crossword grid",
+	Long: `Validate a crossword grid and report all the issues found.
+
+Errors prevent the grid from being solved, warnings do not.
+
+Examples:
+
+$ crogo check "..#,.@.,..."
+error: invalid value at row #1, column #1: @
+
+$ crogo check "...,...,..." --format json
+{"valid":true,"issues":[]}
+`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCheck,
+	SilenceUsage: true,
+}
+
+// errInvalidGrid is returned by the check command when the grid contains errors, so that exit code is not 0.
+var errInvalidGrid = errors.New("invalid grid")
+
+func init() {
+	checkCmd.Flags().StringVarP(&checkFormat, "format", "f", "text", "the output format. Possible values are: text, json")
+	rootCmd.AddCommand(checkCmd)
+}
+
+// jsonReport is the JSON representation of a validation report.
+type jsonReport struct {
+	Valid  bool        `json:"valid"`
+	Issues []jsonIssue `json:"issues"`
+}
+
+// jsonIssue is the JSON representation of a validation issue.
+type jsonIssue struct {
+	Kind     string `json:"kind"`
+	Severity string `json:"severity"`
+	Row      int    `json:"row"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+}
+
+func runCheck(_ *cobra.Command, args []string) error {
+	cells, err := cellsFrom(args[0])
+	if err != nil {
+		return err
+	}
+	report := crogo.Validate(cells, dictionaries.Ukacd())
+	switch checkFormat {
+	case "text":
+		printTextReport(report)
+	case "json":
+		if err := printJsonReport(report); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format: %s", checkFormat)
+	}
+	if report.Err() != nil {
+		return errInvalidGrid
+	}
+	return nil
+}
+
+func printTextReport(report *grid.Report) {
+	if len(report.Issues) == 0 {
+		fmt.Println("No issue found.")
+	}
+	for _, issue := range report.Issues {
+		fmt.Printf("%v: %v\n", issue.Severity(), issue)
+	}
+}
+
+func printJsonReport(report *grid.Report) error {
+	output := jsonReport{Valid: report.Err() == nil, Issues: make([]jsonIssue, 0, len(report.Issues))}
+	for _, issue := range report.Issues {
+		pos := issue.Pos()
+		output.Issues = append(output.Issues, jsonIssue{
+			Kind:     issue.Kind(),
+			Severity: issue.Severity().String(),
+			Row:      pos.Row(),
+			Column:   pos.Column(),
+			Message:  issue.Error(),
+		})
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(output); err != nil {
+		return fmt.Errorf("cannot write report: %w", err)
+	}
+	return nil
+}
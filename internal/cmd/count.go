@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"crogo/pkg/solver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// limit is the maximal number of fills counted by the count command, 0 meaning no limit.
+var limit int
+
+// countSolverName is the name of the solver counting the fills.
+var countSolverName string
+
+// countTimeout is the maximal duration of the count, 0 meaning no limit.
+var countTimeout time.Duration
+
+// countBreakSymmetries indicates whether the fills which are images of each other by a symmetry of the grid shall be
+// counted only once.
+var countBreakSymmetries bool
+
+// countCmd represents the count command.
+var countCmd = &cobra.Command{
+	Use:   "count <GRID>",
+	Short: "Count the fills of a crossword grid",
+	Long: `Count the distinct fills of a crossword grid, without printing them.
+
+Counting stops when the limit is exceeded or the timeout expires, in which case the grid may have more fills than printed.
+
+Examples:
+
+$ crogo count "ABC,...,..." --limit 100
+more than 100 fills (capped)
+`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCount,
+	SilenceUsage: true,
+}
+
+func init() {
+	countCmd.Flags().IntVarP(&limit, "limit", "l", 0, "the maximal number of fills to count; 0 means no limit")
+	countCmd.Flags().StringVarP(&countSolverName, "solver", "s", "logicng", "the desired solver backend. Possible values are: logicng, gini")
+	countCmd.Flags().DurationVarP(&countTimeout, "timeout", "t", 0, "the maximal duration of the count, e.g. 30s; 0 means no limit")
+	countCmd.Flags().BoolVar(&countBreakSymmetries, "break-symmetries", false, "count only one of the fills which are images of each other by a symmetry of the grid, e.g. transposed fills of a square grid")
+	rootCmd.AddCommand(countCmd)
+}
+
+func runCount(_ *cobra.Command, args []string) error {
+	crossword, errCrossword := crosswordFrom(args[0])
+	newSolver, errSolver := solver.NewFactory(countSolverName)
+	if errCrossword != nil || errSolver != nil {
+		return errors.Join(errCrossword, errSolver)
+	}
+	if countBreakSymmetries {
+		crossword = crossword.WithSymmetryBreaking()
+	}
+	ctx := context.Background()
+	if countTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, countTimeout)
+		defer cancel()
+	}
+	result := crossword.CountWithFactory(ctx, newSolver, limit)
+	switch {
+	case result.Exhausted:
+		fmt.Printf("%d fills (exhausted)\n", result.Value)
+	case ctx.Err() != nil:
+		fmt.Printf("at least %d fills (timed out)\n", result.Value)
+	default:
+		fmt.Printf("more than %d fills (capped)\n", result.Value)
+	}
+	return nil
+}
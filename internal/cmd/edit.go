@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"crogo/internal/editor"
+	"crogo/pkg/dictionaries"
+	"crogo/pkg/solver"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// editSolverName is the name of the solver of the editor.
+var editSolverName string
+
+// editColors indicates whether the editor may use ANSI colours.
+var editColors bool
+
+// editCmd represents the edit command.
+var editCmd = &cobra.Command{
+	Use:   "edit <GRID>",
+	Short: "Edit a crossword grid interactively",
+	Long: `Edit a crossword grid interactively: Set cells, lock words into entries, search for fills and word suggestions,
+undo and redo changes.
+
+The solver is kept alive between the commands: The crossword is only encoded again when the block pattern changes.
+
+Examples:
+
+$ crogo edit "...,...,..."
+> lock 1a CAT
+> suggest 1d
+> fill
+> accept
+> quit
+`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runEdit,
+	SilenceUsage: true,
+}
+
+func init() {
+	editCmd.Flags().StringVarP(&editSolverName, "solver", "s", "logicng", "the desired solver backend. Possible values are: logicng, gini")
+	editCmd.Flags().BoolVar(&editColors, "color", false, "distinguish grid letters from fill letters using colours")
+	rootCmd.AddCommand(editCmd)
+}
+
+func runEdit(_ *cobra.Command, args []string) error {
+	cells, err := cellsFrom(args[0])
+	if err != nil {
+		return err
+	}
+	newSolver, err := solver.NewFactory(editSolverName)
+	if err != nil {
+		return err
+	}
+	e, err := editor.New(cells, dictionaries.Ukacd(), newSolver, os.Stdout, editColors)
+	if err != nil {
+		return err
+	}
+	return e.Run(context.Background(), os.Stdin)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"cmp"
+	"crogo/pkg/grid"
+	"crogo/pkg/render"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// heatmapSvg indicates whether the heatmap shall be rendered as SVG.
+var heatmapSvg bool
+
+// heatmapColors indicates whether the text heatmap may use ANSI colours.
+var heatmapColors bool
+
+// heatmapCmd represents the heatmap command.
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap <GRID>",
+	Short: "Show the candidate letters of a crossword grid",
+	Long: `Show the letters which remain possible in each cell of a crossword grid, and the number of words which remain
+possible in each entry, tightest entries first.
+
+Candidates are computed by propagating the constraints of the grid, without search: It takes a fraction of a second
+and reveals the tight corners of the grid. Cells show their candidate letters if there are 1 to 3 of them, or their
+number otherwise.
+
+Examples:
+
+$ crogo heatmap "QI#,...,#.."
+┌───┬───┬───┐
+│1  │2  │███│
+│ Q │ I │███│
+├───┼───┼───┤
+│3  │   │4  │
+│ I │14 │14 │
+├───┼───┼───┤
+│███│5  │   │
+│███│14 │22 │
+└───┴───┴───┘
+1-Across: 1 word
+1-Down: 1 word
+3-Across: 36 words
+2-Down: 36 words
+5-Across: 91 words
+4-Down: 91 words
+
+$ crogo heatmap "QI#,...,#.." --svg > heatmap.svg
+`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runHeatmap,
+	SilenceUsage: true,
+}
+
+func init() {
+	heatmapCmd.Flags().BoolVar(&heatmapSvg, "svg", false, "render as SVG instead of text")
+	heatmapCmd.Flags().BoolVar(&heatmapColors, "color", false, "shade the tight cells using colours, for text rendering")
+	rootCmd.AddCommand(heatmapCmd)
+}
+
+func runHeatmap(_ *cobra.Command, args []string) error {
+	crossword, err := crosswordFrom(args[0])
+	if err != nil {
+		return err
+	}
+	g := crossword.Grid()
+	candidates := crossword.Candidates()
+	if heatmapSvg {
+		return writeRendering(render.SvgHeatmap(g, candidates, render.SvgOptions{}))
+	}
+	var builder strings.Builder
+	builder.WriteString(render.TextHeatmap(g, candidates, render.TextOptions{Colors: heatmapColors}))
+	numberedSlots := g.NumberedSlots()
+	slices.SortStableFunc(numberedSlots, func(a, b grid.NumberedSlot) int {
+		return cmp.Compare(candidates.WordCounts[a.Slot], candidates.WordCounts[b.Slot])
+	})
+	for _, numberedSlot := range numberedSlots {
+		wordCount := candidates.WordCounts[numberedSlot.Slot]
+		unit := "words"
+		if wordCount == 1 {
+			unit = "word"
+		}
+		fmt.Fprintf(&builder, "%v: %d %s\n", numberedSlot, wordCount, unit)
+	}
+	return writeRendering(builder.String())
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"crogo/internal/api"
+	"crogo/pkg/formats/exolve"
+	"crogo/pkg/formats/ipuz"
+	"crogo/pkg/formats/jpz"
+	"crogo/pkg/formats/puz"
+	"crogo/pkg/grid"
+	"crogo/pkg/render"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// solutionPrinter prints the solutions of a crossword as they are found.
+type solutionPrinter interface {
+	// printSolution prints the given solution, found after the given elapsed time since the start of the search.
+	printSolution(solution [][]rune, elapsed time.Duration) error
+	// printEnd prints the outcome of the search, once the iteration is over.
+	printEnd(status api.Status, solutionCount int, elapsed time.Duration) error
+}
+
+// printerFrom returns the printer corresponding to the given format, for the given desired number of solutions. The
+// given clue function, if not nil, gives the clues of the formats supporting them.
+func printerFrom(format string, g *grid.Grid, clueOf func(grid.Entry) string, count int, out io.Writer) (solutionPrinter, error) {
+	switch format {
+	case "ipuz", "puz", "jpz", "exolve", "exolve-html":
+		if count > 1 {
+			return nil, fmt.Errorf("format %s holds a single solution, --count must be 1", format)
+		}
+	}
+	switch format {
+	case "raw":
+		return &textPrinter{out, func(solution [][]rune) string {
+			return fmt.Sprintf("%c\n", solution)
+		}}, nil
+	case "pretty":
+		return &textPrinter{out, func(solution [][]rune) string {
+			return render.Text(g, solution, render.TextOptions{Colors: colors})
+		}}, nil
+	case "json":
+		return &jsonPrinter{g: g, clueOf: clueOf, out: out, solutions: []api.Solution{}}, nil
+	case "ndjson":
+		return &ndjsonPrinter{g: g, clueOf: clueOf, encoder: json.NewEncoder(out)}, nil
+	case "ipuz":
+		return &filePrinter{g, clueOf, out, ipuz.Write}, nil
+	case "puz":
+		return &filePrinter{g, clueOf, out, puz.Write}, nil
+	case "jpz":
+		return &filePrinter{g, clueOf, out, jpz.Write}, nil
+	case "exolve":
+		return &filePrinter{g, clueOf, out, exolve.Write}, nil
+	case "exolve-html":
+		return &filePrinter{g, clueOf, out, exolve.WriteHtml}, nil
+	default:
+		return nil, fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// textPrinter prints solutions for humans.
+type textPrinter struct {
+	out       io.Writer
+	formatted func([][]rune) string
+}
+
+func (p *textPrinter) printSolution(solution [][]rune, _ time.Duration) error {
+	_, err := fmt.Fprint(p.out, p.formatted(solution))
+	return wrapOutputError(err)
+}
+
+func (p *textPrinter) printEnd(status api.Status, solutionCount int, _ time.Duration) error {
+	var err error
+	switch {
+	case status == api.StatusTimedOut:
+		_, err = fmt.Fprintln(p.out, "Timed out.")
+	case status == api.StatusExhausted && solutionCount == 0:
+		_, err = fmt.Fprintln(p.out, "No solution found.")
+	case status == api.StatusExhausted:
+		_, err = fmt.Fprintln(p.out, "No more solution.")
+	}
+	return wrapOutputError(err)
+}
+
+// filePrinter prints each solution as a puzzle file.
+type filePrinter struct {
+	g      *grid.Grid
+	clueOf func(grid.Entry) string
+	out    io.Writer
+	write  func(io.Writer, *grid.Grid, [][]rune, func(grid.Entry) string) error
+}
+
+func (p *filePrinter) printSolution(solution [][]rune, _ time.Duration) error {
+	return p.write(p.out, p.g, solution, p.clueOf)
+}
+
+func (p *filePrinter) printEnd(status api.Status, solutionCount int, _ time.Duration) error {
+	// Not part of the puzzle file, print it on standard error
+	if status == api.StatusCountReached {
+		return nil
+	}
+	return (&textPrinter{os.Stderr, nil}).printEnd(status, solutionCount, 0)
+}
+
+// jsonPrinter prints all the solutions at once, as a single JSON document, once the iteration is over.
+type jsonPrinter struct {
+	g         *grid.Grid
+	clueOf    func(grid.Entry) string
+	out       io.Writer
+	solutions []api.Solution
+}
+
+func (p *jsonPrinter) printSolution(solution [][]rune, elapsed time.Duration) error {
+	p.solutions = append(p.solutions, api.SolutionFrom(p.g, p.clueOf, solution, len(p.solutions), solverName, elapsed))
+	return nil
+}
+
+func (p *jsonPrinter) printEnd(status api.Status, solutionCount int, elapsed time.Duration) error {
+	document := api.Result{Solutions: p.solutions, End: api.End{Status: status, Count: solutionCount,
+		ElapsedMs: elapsed.Milliseconds()}}
+	return wrapOutputError(json.NewEncoder(p.out).Encode(document))
+}
+
+// ndjsonPrinter streams the solutions as they are found, one JSON object per line, followed by the outcome of the
+// search.
+type ndjsonPrinter struct {
+	g             *grid.Grid
+	clueOf        func(grid.Entry) string
+	encoder       *json.Encoder
+	solutionCount int
+}
+
+func (p *ndjsonPrinter) printSolution(solution [][]rune, elapsed time.Duration) error {
+	jsonSolution := api.SolutionFrom(p.g, p.clueOf, solution, p.solutionCount, solverName, elapsed)
+	p.solutionCount++
+	return wrapOutputError(p.encoder.Encode(jsonSolution))
+}
+
+func (p *ndjsonPrinter) printEnd(status api.Status, solutionCount int, elapsed time.Duration) error {
+	end := api.End{Status: status, Count: solutionCount, ElapsedMs: elapsed.Milliseconds()}
+	return wrapOutputError(p.encoder.Encode(end))
+}
+
+// wrapOutputError wraps the given error, if any, as an output error.
+func wrapOutputError(err error) error {
+	if err != nil {
+		return fmt.Errorf("cannot write output: %w", err)
+	}
+	return nil
+}
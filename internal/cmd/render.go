@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"crogo/pkg/render"
+	"crogo/pkg/solver"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// svg indicates whether the grid shall be rendered as SVG.
+var svg bool
+
+// pdf indicates whether the grid shall be rendered as a printable PDF document.
+var pdf bool
+
+// solve indicates whether the grid shall be solved before being rendered.
+var solve bool
+
+// highlight indicates whether the prefilled cells shall be highlighted.
+var highlight bool
+
+// renderSolverName is the name of the solver used to solve the grid before rendering it.
+var renderSolverName string
+
+// renderCmd represents the render command.
+var renderCmd = &cobra.Command{
+	Use:   "render <GRID>",
+	Short: "Render a crossword grid",
+	Long: `Render a crossword grid, blank or solved, with its clue numbers.
+
+Examples:
+
+$ crogo render "A..,...,..#"
+┌───┬───┬───┐
+│1  │2  │3  │
+│ A │   │   │
+├───┼───┼───┤
+│4  │   │   │
+│   │   │   │
+├───┼───┼───┤
+│5  │   │███│
+│   │   │███│
+└───┴───┴───┘
+
+$ crogo render "A..,...,..#" --solve --svg > grid.svg
+
+$ crogo render "A..,...,..#" --pdf > puzzle.pdf # The PDF contains the clue list skeleton and the answer key
+`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runRender,
+	SilenceUsage: true,
+}
+
+// errNoSolution is returned by the render command when the grid to solve has no solution.
+var errNoSolution = errors.New("no solution found")
+
+func init() {
+	renderCmd.Flags().BoolVar(&svg, "svg", false, "render as SVG instead of text")
+	renderCmd.Flags().BoolVar(&pdf, "pdf", false, "render as a printable PDF document with clue list and answer key; Implies --solve")
+	renderCmd.MarkFlagsMutuallyExclusive("svg", "pdf")
+	renderCmd.Flags().BoolVar(&solve, "solve", false, "render the first solution instead of the blank grid")
+	renderCmd.Flags().BoolVar(&highlight, "highlight", false, "highlight the prefilled cells")
+	renderCmd.Flags().StringVarP(&renderSolverName, "solver", "s", "logicng", "the desired solver backend, with --solve. Possible values are: logicng, gini")
+	rootCmd.AddCommand(renderCmd)
+}
+
+func runRender(_ *cobra.Command, args []string) error {
+	crossword, err := crosswordFrom(args[0])
+	if err != nil {
+		return err
+	}
+	var solution [][]rune
+	if solve || pdf {
+		newSolver, err := solver.NewFactory(renderSolverName)
+		if err != nil {
+			return err
+		}
+		found := false
+		for solution = range crossword.SolveWithFactory(context.Background(), newSolver) {
+			found = true
+			break
+		}
+		if !found {
+			return errNoSolution
+		}
+	}
+	if pdf {
+		return render.Pdf(os.Stdout, crossword.Grid(), solution)
+	}
+	var rendering string
+	if svg {
+		rendering = render.Svg(crossword.Grid(), solution, render.SvgOptions{HighlightPrefilled: highlight})
+	} else {
+		rendering = render.Text(crossword.Grid(), solution, render.TextOptions{Colors: highlight})
+	}
+	return writeRendering(rendering)
+}
+
+// writeRendering writes the given rendering to the standard output.
+func writeRendering(rendering string) error {
+	if _, err := fmt.Fprint(os.Stdout, rendering); err != nil {
+		return fmt.Errorf("cannot write rendering: %w", err)
+	}
+	return nil
+}
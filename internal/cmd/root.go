@@ -1,14 +1,24 @@
 package cmd
 
 import (
+	"context"
+	"crogo/internal/api"
+	"crogo/pkg/clues"
 	"crogo/pkg/crogo"
 	"crogo/pkg/dictionaries"
+	"crogo/pkg/formats/ipuz"
+	"crogo/pkg/formats/jpz"
+	"crogo/pkg/formats/puz"
+	"crogo/pkg/grid"
 	"crogo/pkg/solver"
 	"errors"
 	"fmt"
+	"io"
 	"iter"
+	"math/rand/v2"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -19,6 +29,38 @@ var count int
 // solverName is the name of the desired solver.
 var solverName string
 
+// format is the name of the desired output format.
+var format string
+
+// colors indicates whether the output may contain ANSI colours.
+var colors bool
+
+// inputFormat is the format of the grid argument.
+var inputFormat string
+
+// cluesPath is the path of the clue database, if any.
+var cluesPath string
+
+// clueStrategy is the name of the strategy selecting the clues in the clue database.
+var clueStrategy string
+
+// timeout is the maximal duration of the search, 0 meaning no limit.
+var timeout time.Duration
+
+// diversity is the minimal number of entries whose words differ between any two solutions, 0 meaning that solutions
+// only need to differ by one letter.
+var diversity int
+
+// seed is the seed randomising the search, if the seed flag is set.
+var seed uint64
+
+// breakSymmetries indicates whether the solutions which are images of each other by a symmetry of the grid shall be
+// found only once.
+var breakSymmetries bool
+
+// differIn is the comma-separated list of the entries whose words distinguish the solutions, empty meaning all.
+var differIn string
+
 // rootCmd represents the base command when called without any subcommands.
 var rootCmd = &cobra.Command{
 	Use:   "crogo <GRID>",
@@ -38,6 +80,23 @@ $ crogo "ALL,...,..." --count 3 # --count allows to get more than one solution
 [[A L L] [K A A] [A B B]]
 [[A L L] [K A A] [E B B]]
 
+$ crogo "ALL,...,..." --count 3 --differ-in 2d # --differ-in and --diversity require solutions to differ by whole words
+[[A L L] [S E A] [S T Y]]
+[[A L L] [S E A] [S I T]]
+[[A L L] [S E A] [S K Y]]
+
+$ crogo "AB#,...,#.." --format pretty # --format allows to choose how solutions are printed
+┌───┬───┬───┐
+│1  │2  │███│
+│ A │ B │███│
+├───┼───┼───┤
+│3  │   │4  │
+│ W │ A │ X │
+├───┼───┼───┤
+│███│5  │   │
+│███│ A │ I │
+└───┴───┴───┘
+
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: run,
@@ -55,56 +114,172 @@ func Execute() {
 func init() {
 	rootCmd.Flags().IntVarP(&count, "count", "c", 1, "the desired number of solutions")
 	rootCmd.Flags().StringVarP(&solverName, "solver", "s", "logicng", "the desired solver backend. Possible values are: logicng, gini")
+	rootCmd.PersistentFlags().StringVarP(&inputFormat, "input-format", "i", "text", "the grid format. Possible values are: text, ipuz, puz, jpz; For file formats, the grid argument is a file path, '-' meaning standard input")
+	rootCmd.Flags().StringVarP(&format, "format", "f", "raw", "the output format. Possible values are: raw, pretty, json, ndjson, ipuz, puz, jpz, exolve, exolve-html")
+	rootCmd.Flags().BoolVar(&colors, "color", false, "distinguish prefilled letters from solver letters using colours, for pretty format")
+	rootCmd.Flags().StringVar(&cluesPath, "clues", "", "the path of a CSV or TSV clue database (word, clue, source, date) used to clue the solutions, for json, ndjson and file formats")
+	rootCmd.Flags().StringVar(&clueStrategy, "clue-strategy", "recent", "the selection of clues in the clue database. Possible values are: recent, shortest, random")
+	rootCmd.Flags().DurationVarP(&timeout, "timeout", "t", 0, "the maximal duration of the search, e.g. 30s; 0 means no limit")
+	rootCmd.Flags().Uint64Var(&seed, "seed", 0, "the seed randomising the order of the words tried in each slot, so that runs with different seeds give different solutions; The decisions of the solver itself are not randomised; default is no randomisation")
+	rootCmd.Flags().IntVar(&diversity, "diversity", 0, "the minimal number of entries whose words differ between any two solutions; 0 means solutions differ by at least one letter")
+	rootCmd.Flags().BoolVar(&breakSymmetries, "break-symmetries", false, "find only one of the solutions which are images of each other by a symmetry of the grid, e.g. transposed solutions of a square grid")
+	rootCmd.Flags().StringVar(&differIn, "differ-in", "", "the comma-separated entries whose words distinguish the solutions, e.g. 1a,2d; Default is all entries")
 }
 
-func run(_ *cobra.Command, args []string) error {
+func run(cmd *cobra.Command, args []string) error {
 	crossword, errCrossword := crosswordFrom(args[0])
-	s, errSolver := solverFrom(solverName)
+	newSolver, errSolver := solver.NewFactory(solverName)
 	if errCrossword != nil || errSolver != nil {
 		return errors.Join(errCrossword, errSolver)
 	}
-	solutions := crossword.SolveWith(s)
-	iterateAndPrint(solutions)
-	return nil
+	if cmd.Flags().Changed("seed") {
+		crossword = crossword.WithSeed(seed)
+	}
+	if breakSymmetries {
+		crossword = crossword.WithSymmetryBreaking()
+	}
+	clueOf, err := clueOfFrom(cluesPath, clueStrategy)
+	if err != nil {
+		return err
+	}
+	printer, err := printerFrom(format, crossword.Grid(), clueOf, count, os.Stdout)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	solutions, err := solutionsOf(ctx, crossword, newSolver)
+	if err != nil {
+		return err
+	}
+	return iterateAndPrint(ctx, cancel, solutions, printer)
+}
+
+// solutionsOf returns the solutions of the given crossword, as diverse as required by the diversity options, using
+// solvers created by the given factory.
+func solutionsOf(ctx context.Context, crossword *crogo.Crossword, newSolver solver.Factory) (crogo.Solutions, error) {
+	if diversity == 0 && differIn == "" {
+		return crossword.SolveWithFactory(ctx, newSolver), nil
+	}
+	var slots []grid.Slot
+	if differIn != "" {
+		for _, name := range strings.Split(differIn, ",") {
+			numberedSlot, err := crossword.Grid().NumberedSlotNamed(strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			slots = append(slots, numberedSlot.Slot)
+		}
+	}
+	return crossword.SolveDiverse(ctx, newSolver(), crogo.Diversity{Slots: slots, MinDifferentWords: diversity})
 }
 
 func crosswordFrom(crosswordArg string) (*crogo.Crossword, error) {
-	lines := strings.Split(crosswordArg, ",")
-	runes := make([][]rune, len(lines))
-	for i, line := range lines {
-		runes[i] = []rune(line)
+	cells, err := cellsFrom(crosswordArg)
+	if err != nil {
+		return nil, err
 	}
-	crossword, err := crogo.NewCrossword(runes, dictionaries.Ukacd())
+	crossword, err := crogo.NewCrossword(cells, dictionaries.Ukacd())
 	if err != nil {
 		return nil, fmt.Errorf("invalid crossword: %w", err)
 	}
 	return crossword, nil
 }
 
-func solverFrom(solverName string) (solver.ConfigurableSolver, error) {
-	switch solverName {
-	case "logicng":
-		return solver.NewLogicNgSolver(), nil
-	case "gini":
-		return solver.NewGiniSolver(), nil
+// cellsFrom parses the given grid argument according to the input format.
+func cellsFrom(crosswordArg string) ([][]rune, error) {
+	switch inputFormat {
+	case "text":
+		return cellsFromText(crosswordArg), nil
+	case "ipuz":
+		return readFile(crosswordArg, ipuz.Read)
+	case "puz":
+		return readFile(crosswordArg, puz.Read)
+	case "jpz":
+		return readFile(crosswordArg, jpz.Read)
 	default:
-		return nil, fmt.Errorf("unknown solver: %s", solverName)
+		return nil, fmt.Errorf("unknown input format: %s", inputFormat)
 	}
 }
 
-func iterateAndPrint(solutions crogo.Solutions) {
+// cellsFromText parses the given textual grid, a comma-separated list of rows.
+func cellsFromText(crosswordArg string) [][]rune {
+	lines := strings.Split(crosswordArg, ",")
+	cells := make([][]rune, len(lines))
+	for i, line := range lines {
+		cells[i] = []rune(line)
+	}
+	return cells
+}
+
+// readFile reads the grid file at the given path - or the standard input if path is "-" - using the given reader.
+func readFile(path string, read func(io.Reader) ([][]rune, error)) ([][]rune, error) {
+	if path == "-" {
+		return read(os.Stdin)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open grid file: %w", err)
+	}
+	defer file.Close()
+	return read(file)
+}
+
+// clueOfFrom returns the clue function selecting clues from the given clue database with the given strategy, or nil if
+// there is no clue database.
+func clueOfFrom(path string, strategyName string) (func(grid.Entry) string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	var strategy clues.Strategy
+	switch strategyName {
+	case "recent":
+		strategy = clues.MostRecent
+	case "shortest":
+		strategy = clues.Shortest
+	case "random":
+		strategy = clues.Random(rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())))
+	default:
+		return nil, fmt.Errorf("unknown clue strategy: %s", strategyName)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open clue database: %w", err)
+	}
+	defer file.Close()
+	database, err := clues.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	return database.ClueOf(strategy), nil
+}
+
+// iterateAndPrint prints the solutions until the desired count is reached, the solutions are exhausted or the timeout
+// expires. Timeout is measured from the start of the search, it is enforced using the given context cancel function.
+func iterateAndPrint(ctx context.Context, cancel context.CancelFunc, solutions crogo.Solutions, printer solutionPrinter) error {
+	start := time.Now()
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, cancel)
+		defer timer.Stop()
+	}
 	getNextSolution, stop := iter.Pull(solutions)
 	defer stop()
-	for i := range count {
+	status := api.StatusCountReached
+	solutionCount := 0
+	for solutionCount < count {
 		nextSolution, found := getNextSolution()
 		if !found {
-			if i == 0 {
-				fmt.Println("No solution found.")
+			if ctx.Err() != nil {
+				status = api.StatusTimedOut
 			} else {
-				fmt.Println("No more solution.")
+				status = api.StatusExhausted
 			}
 			break
 		}
-		fmt.Printf("%c\n", nextSolution)
+		solutionCount++
+		if err := printer.printSolution(nextSolution, time.Since(start)); err != nil {
+			return err
+		}
 	}
+	return printer.printEnd(status, solutionCount, time.Since(start))
 }
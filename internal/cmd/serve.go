@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"crogo/internal/server"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// addr is the address the server listens on.
+var addr string
+
+// maxSolves is the maximal number of simultaneous solves of the server.
+var maxSolves int
+
+// serveCmd represents the serve command.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the solver over HTTP",
+	Long: `Serve the solver over HTTP, e.g. for a web editor.
+
+Endpoints:
+
+POST /solve          Solve a grid, e.g. {"grid": ["AB#", "..."], "dictionary": "ukacd", "solver": "logicng",
+                     "count": 1, "timeout": "30s"}; Only the grid is mandatory
+POST /solve/stream   Same as /solve but stream the solutions as server-sent events: "started" gives the
+                     stream id, then "solution" and "heartbeat" events are sent until the "end" event;
+                     "count" is unlimited by default
+POST /solve/stream/{id}/stop
+                     Stop the enumeration of a stream
+GET  /dictionaries   List the available dictionaries
+GET  /words          List the words matching a pattern, e.g. /words?pattern=A..E&limit=10
+
+Examples:
+
+$ crogo serve --addr localhost:8080 &
+$ curl -d '{"grid": ["AB#", "..."]}' localhost:8080/solve
+$ curl -N -d '{"grid": ["AB#", "..."], "count": 10}' localhost:8080/solve/stream
+`,
+	Args:         cobra.NoArgs,
+	RunE:         runServe,
+	SilenceUsage: true,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&addr, "addr", ":8080", "the address to listen on")
+	serveCmd.Flags().IntVar(&maxSolves, "max-solves", runtime.NumCPU(), "the maximal number of simultaneous solves; Other solve requests wait")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(_ *cobra.Command, _ []string) error {
+	return listenAndServe(addr, server.New(server.Builtin(), maxSolves).Handler())
+}
+
+// listenAndServe serves the given handler on the given address until interrupted. Requests in progress are cancelled
+// on interrupt.
+func listenAndServe(addr string, handler http.Handler) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	httpServer := &http.Server{
+		Addr:        addr,
+		Handler:     handler,
+		BaseContext: func(_ net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		_ = httpServer.Shutdown(context.Background())
+	}()
+	fmt.Fprintf(os.Stderr, "Listening on %s\n", addrUrl(addr))
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("cannot serve: %w", err)
+	}
+	return nil
+}
+
+// addrUrl returns the URL corresponding to the given address.
+func addrUrl(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "http://localhost" + addr
+	}
+	return "http://" + addr
+}
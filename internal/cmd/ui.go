@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"crogo/internal/server"
+	"crogo/internal/ui"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// uiAddr is the address the editor is served on.
+var uiAddr string
+
+// uiMaxSolves is the maximal number of simultaneous solves of the server of the editor.
+var uiMaxSolves int
+
+// uiCmd represents the ui command.
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "Serve the grid editor in the browser",
+	Long: `Serve the grid editor in the browser, along with the solving service used by the editor, see serve command.
+
+The editor allows to draw blocks, type letters, fill the grid, lock entries and get word suggestions for an entry.
+
+Examples:
+
+$ crogo ui --addr localhost:8080
+Listening on http://localhost:8080
+`,
+	Args:         cobra.NoArgs,
+	RunE:         runUi,
+	SilenceUsage: true,
+}
+
+func init() {
+	uiCmd.Flags().StringVar(&uiAddr, "addr", "localhost:8080", "the address to listen on")
+	uiCmd.Flags().IntVar(&uiMaxSolves, "max-solves", runtime.NumCPU(), "the maximal number of simultaneous solves; Other solve requests wait")
+	rootCmd.AddCommand(uiCmd)
+}
+
+func runUi(_ *cobra.Command, _ []string) error {
+	return listenAndServe(uiAddr, ui.Handler(server.New(server.Builtin(), uiMaxSolves).Handler()))
+}
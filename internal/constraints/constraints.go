@@ -8,6 +8,7 @@
 //     each slot variable - i.e. a representation of a (slot,word) pair - is equivalent to a
 //     conjunction (= and) of cell variables - i.e. (cell,letter) pairs.
 //   - Prefilled cells must be kept as is.
+//   - Optionally, fills which are images of each other by a symmetry of the grid must be found only once.
 //
 // Implementation note: Functions here add rules to the solver passed as parameter. Although having
 // just a factory of constraints, to be applied separately, would be nice, it does not scale in
@@ -17,15 +18,19 @@ package constraints
 
 import (
 	"crogo/internal/alphabet"
-	. "crogo/internal/grid"
 	. "crogo/internal/variables"
+	. "crogo/pkg/grid"
 	"crogo/pkg/solver"
+	"math/rand/v2"
+	"slices"
 )
 
 type Constraints struct {
 	grid      *Grid
 	variables *Variables
 	words     []string
+	// seed, if not nil, is the seed used to shuffle the order in which the words of each slot are added.
+	seed *uint64
 }
 
 // cellLiteralsBufferCapacity is the capacity of the buffer used to store cell literals corresponding to a word in a
@@ -34,7 +39,14 @@ const cellLiteralsBufferCapacity = 20
 
 // NewConstraints constructs a new instance of Constraints.
 func NewConstraints(grid *Grid, variables *Variables, words []string) *Constraints {
-	return &Constraints{grid, variables, words}
+	return &Constraints{grid, variables, words, nil}
+}
+
+// WithSeed returns a copy of these constraints whose words are numbered and added in a different order for each slot,
+// shuffled using the given seed, see Variables.WithSeed. The solutions are then found in a different order, which is the
+// same for the same seed.
+func (c *Constraints) WithSeed(seed uint64) *Constraints {
+	return &Constraints{c.grid, c.variables.WithSeed(seed), c.words, &seed}
 }
 
 // AddOneLetterOrBlockPerCellClausesTo adds the clauses ensuring that each cell must contain exactly one letter from the
@@ -60,21 +72,38 @@ func (c *Constraints) AddOneLetterOrBlockPerCellClausesTo(solverConfigurer solve
 func (c *Constraints) AddOneWordPerSlotClausesTo(solverConfigurer solver.Configurer) {
 	slotLiteralsBuffer := make([]solver.Literal, 0, len(c.words))
 	cellLiteralsBuffer := make([]solver.Literal, 0, cellLiteralsBufferCapacity)
+	wordIndexesByLength := c.wordIndexesByLength()
 	for slotIndex, slot := range c.grid.Slots() {
-		for wordIndex, word := range c.words {
-			if len(word) == slot.Length() {
-				slotLiteral := solver.Literal(c.variables.RepresentingSlot(slotIndex, wordIndex))
-				slotLiteralsBuffer = append(slotLiteralsBuffer, slotLiteral)
-				c.fillCellLiteralsConjunction(&cellLiteralsBuffer, slot, word)
-				solverConfigurer.AddAnd(slotLiteral, cellLiteralsBuffer)
-				cellLiteralsBuffer = cellLiteralsBuffer[:0]
-			} // else skip this word since it obviously doesn't match the slot
+		// Only words of the slot length are considered since other words obviously don't match the slot
+		wordIndexes := wordIndexesByLength[slot.Length()]
+		if c.seed != nil {
+			wordIndexes = slices.Clone(wordIndexes)
+			random := rand.New(rand.NewPCG(*c.seed, uint64(slotIndex)))
+			random.Shuffle(len(wordIndexes), func(i, j int) {
+				wordIndexes[i], wordIndexes[j] = wordIndexes[j], wordIndexes[i]
+			})
+		}
+		for _, wordIndex := range wordIndexes {
+			slotLiteral := solver.Literal(c.variables.RepresentingSlot(slotIndex, wordIndex))
+			slotLiteralsBuffer = append(slotLiteralsBuffer, slotLiteral)
+			c.fillCellLiteralsConjunction(&cellLiteralsBuffer, slot, c.words[wordIndex])
+			solverConfigurer.AddAnd(slotLiteral, cellLiteralsBuffer)
+			cellLiteralsBuffer = cellLiteralsBuffer[:0]
 		}
 		solverConfigurer.AddExactlyOne(slotLiteralsBuffer)
 		slotLiteralsBuffer = slotLiteralsBuffer[:0]
 	}
 }
 
+// wordIndexesByLength returns the indexes of the words in the word list, grouped by word length.
+func (c *Constraints) wordIndexesByLength() map[int][]int {
+	wordIndexesByLength := make(map[int][]int)
+	for wordIndex, word := range c.words {
+		wordIndexesByLength[len(word)] = append(wordIndexesByLength[len(word)], wordIndex)
+	}
+	return wordIndexesByLength
+}
+
 // fillCellLiteralsConjunction fills the given slice with the cell literals whose conjunction (= and) is equivalent to
 // the slot variable of the given slot and word.
 //
@@ -113,3 +142,75 @@ func (c *Constraints) AddInputGridConstraintsAreSatisfiedClausesTo(solverConfigu
 		}
 	}
 }
+
+// AddSymmetryBreakingClausesTo adds the clauses ensuring that, among the fills which are images of each other by the
+// symmetries of the grid preserving words, only the smallest one in lexicographic order is kept. Grid cells are
+// compared in reading order, letters in alphabetical order. See Grid.Symmetries and Grid.PreservesWords.
+//
+// Auxiliary variables are numbered from the given variable, which must be greater than the variables of the problem.
+// Function returns the first variable after the auxiliary variables.
+func (c *Constraints) AddSymmetryBreakingClausesTo(solverConfigurer solver.Configurer,
+	firstVariable solver.Variable) solver.Variable {
+	nextVariable := firstVariable
+	for _, symmetry := range c.grid.Symmetries() {
+		if c.grid.PreservesWords(symmetry) {
+			nextVariable = c.addLexLeaderClausesTo(solverConfigurer, symmetry, nextVariable)
+		}
+	}
+	return nextVariable
+}
+
+// addLexLeaderClausesTo adds the clauses ensuring that the fill is smaller than or equal to its image by the given
+// symmetry, in lexicographic order. Auxiliary variables are numbered from the given variable. Function returns the
+// first variable after the auxiliary variables.
+//
+// Auxiliary variable equal[k] implies that the k first compared cells are equal to their images: It implies that
+// cell k is not greater than its image, and, along with cell k being equal to its image, implies equal[k+1]. equal[0]
+// is always true, it is omitted.
+func (c *Constraints) addLexLeaderClausesTo(solverConfigurer solver.Configurer, symmetry Symmetry,
+	firstVariable solver.Variable) solver.Variable {
+	var positions []Pos
+	for row := 0; row < c.grid.RowCount(); row++ {
+		for column := 0; column < c.grid.ColumnCount(); column++ {
+			pos := NewPos(column, row)
+			image := symmetry.Apply(pos, c.grid.RowCount(), c.grid.ColumnCount())
+			// Blocks and cells mapped to themselves are always equal to their images
+			if c.grid.LetterAt(row, column) != CellBlock && image != pos {
+				positions = append(positions, pos)
+			}
+		}
+	}
+	nextVariable := firstVariable
+	var equal solver.Literal
+	for k, pos := range positions {
+		image := symmetry.Apply(pos, c.grid.RowCount(), c.grid.ColumnCount())
+		var nextEqual solver.Literal
+		if k < len(positions)-1 {
+			nextEqual = solver.Literal(nextVariable)
+			nextVariable++
+		}
+		for letterIndex := 0; letterIndex < alphabet.LetterCount(); letterIndex++ {
+			cellLiteral := solver.Literal(c.variables.RepresentingCell(pos.Row(), pos.Column(), letterIndex))
+			imageLiteral := solver.Literal(c.variables.RepresentingCell(image.Row(), image.Column(), letterIndex))
+			prefix := []solver.Literal{cellLiteral.Negated()}
+			if equal != 0 {
+				prefix = append(prefix, equal.Negated())
+			}
+			// equal[k] ∧ cell = letter ⇒ image >= letter
+			if letterIndex > 0 {
+				clause := slices.Clone(prefix)
+				for greaterIndex := letterIndex; greaterIndex < alphabet.LetterCount(); greaterIndex++ {
+					greaterLiteral := c.variables.RepresentingCell(image.Row(), image.Column(), greaterIndex)
+					clause = append(clause, solver.Literal(greaterLiteral))
+				}
+				solverConfigurer.AddClause(clause)
+			}
+			// equal[k] ∧ cell = letter ∧ image = letter ⇒ equal[k+1]
+			if nextEqual != 0 {
+				solverConfigurer.AddClause(append(slices.Clone(prefix), imageLiteral.Negated(), nextEqual))
+			}
+		}
+		equal = nextEqual
+	}
+	return nextVariable
+}
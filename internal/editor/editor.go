@@ -0,0 +1,409 @@
+// Package editor implements the interactive grid editor of the edit command, a read-eval-print loop constructing a
+// grid step by step.
+//
+// The crossword is encoded once per block pattern into an incremental solver: The letters of the grid are passed to
+// the solver as assumptions, so that setting cells or locking entries does not require encoding the problem again.
+package editor
+
+import (
+	"bufio"
+	"context"
+	"crogo/internal/alphabet"
+	"crogo/pkg/crogo"
+	"crogo/pkg/grid"
+	"crogo/pkg/render"
+	"crogo/pkg/solver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Default arguments of the commands.
+const (
+	defaultFillCount       = 1
+	defaultSuggestionLimit = 20
+)
+
+// prompt is printed before reading each command.
+const prompt = "> "
+
+// help describes the commands.
+const help = `Commands:
+  show                      show the grid, with the pending fill if any
+  set <row> <column> <cell> set a cell, where cell is a letter, '.' (empty) or '#' (block); Indexes start at 0
+  lock <entry> <word>       write a word in an entry, e.g. "lock 1a CAT" or "lock 2-Down DOG"
+  fill [count]              search for fills of the grid, the last one being pending
+  accept                    write the pending fill in the grid
+  suggest <entry> [limit]   list the words matching an entry
+  undo                      undo the last change of the grid
+  redo                      redo the last undone change of the grid
+  help                      show this help
+  quit                      quit the editor
+`
+
+// errQuit is returned by the quit command to end the loop.
+var errQuit = errors.New("quit")
+
+// Editor is an interactive grid editor.
+type Editor struct {
+	words     []string
+	newSolver solver.Factory
+	out       io.Writer
+	colors    bool
+	// cells is the current grid.
+	cells [][]rune
+	// fill is the last fill found, not written in the grid yet, if any.
+	fill [][]rune
+	// undoStack and redoStack are the previous and the undone versions of the grid.
+	undoStack [][][]rune
+	redoStack [][][]rune
+	// session is the solving session of the current block pattern, created on first fill.
+	session *crogo.Session
+}
+
+// New creates a new Editor of the given cells, filled with the given words using solvers created by the given factory,
+// which must create solver.IncrementalSolver instances. Editor writes to the given writer, using ANSI colours if colors
+// is true.
+func New(cells [][]rune, words []string, newSolver solver.Factory, out io.Writer, colors bool) (*Editor, error) {
+	if _, err := grid.NewGrid(cells); err != nil {
+		return nil, err
+	}
+	return &Editor{words: words, newSolver: newSolver, out: out, colors: colors, cells: cloneCells(cells)}, nil
+}
+
+// Cells returns a copy of the current grid.
+func (e *Editor) Cells() [][]rune {
+	return cloneCells(e.cells)
+}
+
+// Run reads commands from the given reader and executes them until the quit command, the end of input or the given
+// context is done. Errors of the commands are printed and do not stop the loop; Only output errors do.
+func (e *Editor) Run(ctx context.Context, in io.Reader) error {
+	if err := e.show(); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(in)
+	for ctx.Err() == nil {
+		if _, err := fmt.Fprint(e.out, prompt); err != nil {
+			return wrapOutputError(err)
+		}
+		if !scanner.Scan() {
+			break
+		}
+		err := e.Execute(ctx, scanner.Text())
+		if errors.Is(err, errQuit) {
+			return nil
+		}
+		var outputErr *outputError
+		if errors.As(err, &outputErr) {
+			return err
+		}
+		if err != nil {
+			if _, err = fmt.Fprintf(e.out, "Error: %v\n", err); err != nil {
+				return wrapOutputError(err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("cannot read command: %w", err)
+	}
+	return nil
+}
+
+// Execute executes the given command line.
+func (e *Editor) Execute(ctx context.Context, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	command, args := fields[0], fields[1:]
+	switch command {
+	case "show":
+		return e.show()
+	case "set":
+		return e.set(args)
+	case "lock":
+		return e.lock(args)
+	case "fill":
+		return e.fillGrid(ctx, args)
+	case "accept":
+		return e.accept()
+	case "suggest":
+		return e.suggest(args)
+	case "undo":
+		return e.undo()
+	case "redo":
+		return e.redo()
+	case "help":
+		return e.print(help)
+	case "quit", "exit":
+		return errQuit
+	default:
+		return fmt.Errorf("unknown command: %s; Type help to list the commands", command)
+	}
+}
+
+// show prints the current grid, with the pending fill if any.
+func (e *Editor) show() error {
+	g, err := grid.NewGrid(e.cells)
+	if err != nil {
+		return err
+	}
+	return e.print(render.Text(g, e.fill, render.TextOptions{Colors: e.colors}))
+}
+
+// set sets the value of a cell.
+func (e *Editor) set(args []string) error {
+	if len(args) != 3 {
+		return errors.New("usage: set <row> <column> <cell>")
+	}
+	row, errRow := indexFrom(args[0], "row", len(e.cells))
+	column, errColumn := indexFrom(args[1], "column", len(e.cells[0]))
+	value, errValue := cellFrom(args[2])
+	if err := errors.Join(errRow, errColumn, errValue); err != nil {
+		return err
+	}
+	cells := cloneCells(e.cells)
+	cells[row][column] = value
+	return e.change(cells)
+}
+
+// lock writes a word in an entry.
+func (e *Editor) lock(args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: lock <entry> <word>")
+	}
+	slot, err := e.slotFrom(args[0])
+	if err != nil {
+		return err
+	}
+	word := []rune(strings.ToUpper(args[1]))
+	if len(word) != slot.Length() {
+		return fmt.Errorf("%v has %d cells but %s has %d letters", slot, slot.Length(), args[1], len(word))
+	}
+	cells := cloneCells(e.cells)
+	for i, pos := range slot.Positions() {
+		if !alphabet.Contains(word[i]) {
+			return fmt.Errorf("invalid letter: %c", word[i])
+		}
+		cells[pos.Row()][pos.Column()] = word[i]
+	}
+	return e.change(cells)
+}
+
+// fillGrid searches for fills of the grid and prints them. The last fill found becomes the pending fill.
+func (e *Editor) fillGrid(ctx context.Context, args []string) error {
+	count := defaultFillCount
+	if len(args) > 0 {
+		var err error
+		if count, err = strconv.Atoi(args[0]); err != nil || count < 1 {
+			return fmt.Errorf("invalid count: %s", args[0])
+		}
+	}
+	session, err := e.currentSession()
+	if err != nil {
+		return err
+	}
+	solutions, err := session.Solutions(ctx, e.cells)
+	if err != nil {
+		return err
+	}
+	found := 0
+	for solution := range solutions {
+		e.fill = solution
+		found++
+		if err = e.show(); err != nil {
+			return err
+		}
+		if found == count {
+			break
+		}
+	}
+	if found == 0 {
+		e.fill = nil
+		return e.print("No fill found.\n")
+	}
+	return e.print("Type accept to write the last fill in the grid.\n")
+}
+
+// accept writes the pending fill in the grid.
+func (e *Editor) accept() error {
+	if e.fill == nil {
+		return errors.New("no pending fill; Type fill to search for one")
+	}
+	return e.change(e.fill)
+}
+
+// suggest prints the words matching an entry of the current grid.
+func (e *Editor) suggest(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("usage: suggest <entry> [limit]")
+	}
+	limit := defaultSuggestionLimit
+	if len(args) == 2 {
+		var err error
+		if limit, err = strconv.Atoi(args[1]); err != nil || limit < 1 {
+			return fmt.Errorf("invalid limit: %s", args[1])
+		}
+	}
+	slot, err := e.slotFrom(args[0])
+	if err != nil {
+		return err
+	}
+	positions := slot.Positions()
+	pattern := make([]rune, len(positions))
+	for i, pos := range positions {
+		pattern[i] = e.cells[pos.Row()][pos.Column()]
+	}
+	var suggestions []string
+	for _, word := range e.words {
+		if len(suggestions) == limit {
+			break
+		}
+		if grid.Matches(word, pattern) {
+			suggestions = append(suggestions, word)
+		}
+	}
+	if len(suggestions) == 0 {
+		return e.print(fmt.Sprintf("No word matches %v (%s).\n", slot, string(pattern)))
+	}
+	return e.print(strings.Join(suggestions, "\n") + "\n")
+}
+
+// undo restores the previous version of the grid.
+func (e *Editor) undo() error {
+	if len(e.undoStack) == 0 {
+		return errors.New("nothing to undo")
+	}
+	e.redoStack = append(e.redoStack, e.cells)
+	e.cells = e.undoStack[len(e.undoStack)-1]
+	e.undoStack = e.undoStack[:len(e.undoStack)-1]
+	e.fill = nil
+	return e.show()
+}
+
+// redo restores the last undone version of the grid.
+func (e *Editor) redo() error {
+	if len(e.redoStack) == 0 {
+		return errors.New("nothing to redo")
+	}
+	e.undoStack = append(e.undoStack, e.cells)
+	e.cells = e.redoStack[len(e.redoStack)-1]
+	e.redoStack = e.redoStack[:len(e.redoStack)-1]
+	e.fill = nil
+	return e.show()
+}
+
+// change replaces the grid by the given cells, if they are valid, and shows it.
+func (e *Editor) change(cells [][]rune) error {
+	if _, err := grid.NewGrid(cells); err != nil {
+		return err
+	}
+	e.undoStack = append(e.undoStack, e.cells)
+	e.redoStack = nil
+	e.cells = cells
+	e.fill = nil
+	return e.show()
+}
+
+// currentSession returns the solving session of the block pattern of the current grid, encoding the crossword if the
+// block pattern has changed since the last fill.
+func (e *Editor) currentSession() (*crogo.Session, error) {
+	pattern := blockPattern(e.cells)
+	if e.session != nil && slices.EqualFunc(pattern, e.session.Grid().Cells(), slices.Equal) {
+		return e.session, nil
+	}
+	crossword, err := crogo.NewCrossword(pattern, e.words)
+	if err != nil {
+		return nil, fmt.Errorf("invalid crossword: %w", err)
+	}
+	if err = e.print("Encoding the crossword...\n"); err != nil {
+		return nil, err
+	}
+	session, err := crossword.NewSession(e.newSolver())
+	if err != nil {
+		return nil, fmt.Errorf("cannot create solving session: %w", err)
+	}
+	e.session = session
+	return session, nil
+}
+
+// slotFrom returns the slot of the current grid designated by the given argument, see grid.Grid.NumberedSlotNamed.
+func (e *Editor) slotFrom(arg string) (grid.NumberedSlot, error) {
+	g, err := grid.NewGrid(e.cells)
+	if err != nil {
+		return grid.NumberedSlot{}, err
+	}
+	return g.NumberedSlotNamed(arg)
+}
+
+// print writes the given text.
+func (e *Editor) print(text string) error {
+	_, err := fmt.Fprint(e.out, text)
+	return wrapOutputError(err)
+}
+
+// outputError is an error writing the output of the editor.
+type outputError struct {
+	err error
+}
+
+func (e *outputError) Error() string {
+	return fmt.Sprintf("cannot write output: %v", e.err)
+}
+
+func (e *outputError) Unwrap() error {
+	return e.err
+}
+
+// wrapOutputError wraps the given error, if any, as an output error.
+func wrapOutputError(err error) error {
+	if err != nil {
+		return &outputError{err}
+	}
+	return nil
+}
+
+// indexFrom parses the given index argument, which must be lower than the given bound.
+func indexFrom(arg string, name string, bound int) (int, error) {
+	index, err := strconv.Atoi(arg)
+	if err != nil || index < 0 || index >= bound {
+		return 0, fmt.Errorf("invalid %s: %s; Expected an index between 0 and %d", name, arg, bound-1)
+	}
+	return index, nil
+}
+
+// cellFrom parses the given cell argument.
+func cellFrom(arg string) (rune, error) {
+	value, size := utf8.DecodeRuneInString(strings.ToUpper(arg))
+	if size != len(arg) || (value != grid.CellBlock && value != grid.CellEmpty && !alphabet.Contains(value)) {
+		return 0, fmt.Errorf("invalid cell: %s; Expected a letter, '.' or '#'", arg)
+	}
+	return value, nil
+}
+
+// blockPattern returns the given cells without their letters.
+func blockPattern(cells [][]rune) [][]rune {
+	pattern := cloneCells(cells)
+	for _, row := range pattern {
+		for i, cell := range row {
+			if cell != grid.CellBlock {
+				row[i] = grid.CellEmpty
+			}
+		}
+	}
+	return pattern
+}
+
+// cloneCells returns a deep copy of the given cells.
+func cloneCells(cells [][]rune) [][]rune {
+	clone := make([][]rune, len(cells))
+	for i, row := range cells {
+		clone[i] = slices.Clone(row)
+	}
+	return clone
+}
@@ -0,0 +1,117 @@
+package editor
+
+import (
+	"context"
+	"crogo/pkg/solver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+var testingWords = []string{"AAA", "BBB", "CDE", "ABC", "ABD", "ABE"}
+
+func newTestingEditor(t *testing.T, out *strings.Builder) *Editor {
+	cells := [][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	}
+	editor, err := New(cells, testingWords, solver.NewGiniSolver, out, false)
+	require.NoError(t, err)
+	return editor
+}
+
+func TestRun(t *testing.T) {
+	var out strings.Builder
+	editor := newTestingEditor(t, &out)
+
+	in := strings.NewReader("lock 1a aaa\nset 1 0 b\nfill\naccept\nquit\nshow\n")
+	require.NoError(t, editor.Run(context.Background(), in))
+
+	assert.Equal(t, [][]rune{
+		{'A', 'A', 'A'},
+		{'B', 'B', 'B'},
+		{'C', 'D', 'E'},
+	}, editor.Cells())
+	assert.Contains(t, out.String(), "Type accept to write the last fill in the grid.\n")
+	assert.True(t, strings.HasSuffix(out.String(), "> "), "commands after quit shall not be executed")
+}
+
+func TestRun_PrintsErrors(t *testing.T) {
+	var out strings.Builder
+	editor := newTestingEditor(t, &out)
+
+	in := strings.NewReader("set 3 0 A\nlock 1a AB\nlock 7a ABC\naccept\nundo\nfoo\n")
+	require.NoError(t, editor.Run(context.Background(), in))
+
+	output := out.String()
+	assert.Contains(t, output, "Error: invalid row: 3; Expected an index between 0 and 2\n")
+	assert.Contains(t, output, "Error: 1-Across has 3 cells but AB has 2 letters\n")
+	assert.Contains(t, output, "Error: unknown entry: 7a\n")
+	assert.Contains(t, output, "Error: no pending fill; Type fill to search for one\n")
+	assert.Contains(t, output, "Error: nothing to undo\n")
+	assert.Contains(t, output, "Error: unknown command: foo; Type help to list the commands\n")
+}
+
+func TestExecute_UndoRedo(t *testing.T) {
+	var out strings.Builder
+	editor := newTestingEditor(t, &out)
+	ctx := context.Background()
+
+	require.NoError(t, editor.Execute(ctx, "set 0 0 A"))
+	require.NoError(t, editor.Execute(ctx, "lock 2-Down BBB"))
+	require.NoError(t, editor.Execute(ctx, "undo"))
+	assert.Equal(t, [][]rune{{'A', '.', '.'}, {'.', '.', '.'}, {'.', '.', '.'}}, editor.Cells())
+	require.NoError(t, editor.Execute(ctx, "undo"))
+	assert.Equal(t, [][]rune{{'.', '.', '.'}, {'.', '.', '.'}, {'.', '.', '.'}}, editor.Cells())
+	require.NoError(t, editor.Execute(ctx, "redo"))
+	require.NoError(t, editor.Execute(ctx, "redo"))
+	assert.Equal(t, [][]rune{{'A', 'B', '.'}, {'.', 'B', '.'}, {'.', 'B', '.'}}, editor.Cells())
+	assert.EqualError(t, editor.Execute(ctx, "redo"), "nothing to redo")
+}
+
+func TestExecute_Fill(t *testing.T) {
+	var out strings.Builder
+	editor := newTestingEditor(t, &out)
+	ctx := context.Background()
+
+	require.NoError(t, editor.Execute(ctx, "set 0 0 C"))
+	out.Reset()
+	require.NoError(t, editor.Execute(ctx, "fill"))
+	assert.Equal(t, "Encoding the crossword...\nNo fill found.\n", out.String())
+
+	// Session is kept while the block pattern does not change
+	require.NoError(t, editor.Execute(ctx, "set 0 0 A"))
+	out.Reset()
+	require.NoError(t, editor.Execute(ctx, "fill 10"))
+	assert.NotContains(t, out.String(), "Encoding")
+	assert.Equal(t, 3, strings.Count(out.String(), "┌"))
+
+	// Session is recreated when the block pattern changes
+	require.NoError(t, editor.Execute(ctx, "set 1 1 #"))
+	out.Reset()
+	require.NoError(t, editor.Execute(ctx, "fill"))
+	assert.True(t, strings.HasPrefix(out.String(), "Encoding the crossword...\n"))
+	assert.Contains(t, out.String(), "Type accept")
+}
+
+func TestExecute_Suggest(t *testing.T) {
+	var out strings.Builder
+	editor := newTestingEditor(t, &out)
+	ctx := context.Background()
+
+	require.NoError(t, editor.Execute(ctx, "set 0 1 B"))
+	out.Reset()
+	require.NoError(t, editor.Execute(ctx, "suggest 1a 2"))
+	assert.Equal(t, "BBB\nABC\n", out.String())
+
+	out.Reset()
+	require.NoError(t, editor.Execute(ctx, "suggest 2d"))
+	assert.Equal(t, "BBB\n", out.String())
+
+	require.NoError(t, editor.Execute(ctx, "set 0 0 Z"))
+	out.Reset()
+	require.NoError(t, editor.Execute(ctx, "suggest 1A"))
+	assert.Equal(t, "No word matches 1-Across (ZB.).\n", out.String())
+}
@@ -0,0 +1,311 @@
+// Package server exposes the crossword solver as a local HTTP service.
+//
+// Endpoints are:
+//
+//   - POST /solve: solves the grid given in the JSON request body, see solveRequest, and returns an api.Result;
+//   - POST /solve/stream: same as POST /solve but streams the solutions as server-sent events, see solveStream;
+//   - POST /solve/stream/{id}/stop: stops the stream with the given identifier;
+//   - GET /dictionaries: lists the available dictionaries;
+//   - GET /words?pattern=A..E&dictionary=ukacd&limit=100: lists the words matching a pattern, '.' being any letter.
+//
+// Errors are returned as a JSON object with an "error" field.
+package server
+
+import (
+	"context"
+	"crogo/internal/api"
+	"crogo/pkg/crogo"
+	"crogo/pkg/dictionaries"
+	"crogo/pkg/grid"
+	"crogo/pkg/solver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default values of the solve request fields.
+const (
+	defaultDictionary = "ukacd"
+	defaultSolver     = "logicng"
+	defaultCount      = 1
+	defaultWordLimit  = 100
+)
+
+// maxRequestSize is the maximal size of the body of a solve request, in bytes.
+const maxRequestSize = 1 << 20
+
+// Dictionary is a named word list, loaded on first use.
+type Dictionary struct {
+	Name  string
+	words func() []string
+}
+
+// NewDictionary creates a dictionary whose words are loaded by the given function on first use.
+func NewDictionary(name string, load func() []string) *Dictionary {
+	return &Dictionary{name, sync.OnceValue(load)}
+}
+
+// Builtin returns the builtin dictionaries.
+func Builtin() []*Dictionary {
+	return []*Dictionary{NewDictionary("ukacd", dictionaries.Ukacd)}
+}
+
+// Server is the HTTP solving service.
+type Server struct {
+	dictionaries []*Dictionary
+	// solves limits the number of simultaneous SAT solves: A solve holds a token of the channel while running.
+	solves chan struct{}
+	// streams are the running streams, indexed by identifier.
+	streams      map[string]*stream
+	streamsMutex sync.Mutex
+	// heartbeatInterval is the interval between two heartbeat events of a stream.
+	heartbeatInterval time.Duration
+}
+
+// New creates a new server using the given dictionaries and running at most maxSolves solves simultaneously.
+func New(dictionaries []*Dictionary, maxSolves int) *Server {
+	return &Server{
+		dictionaries:      dictionaries,
+		solves:            make(chan struct{}, max(1, maxSolves)),
+		streams:           make(map[string]*stream),
+		heartbeatInterval: defaultHeartbeatInterval,
+	}
+}
+
+// Handler returns the HTTP handler of the server.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /solve", s.solve)
+	mux.HandleFunc("POST /solve/stream", s.solveStream)
+	mux.HandleFunc("POST /solve/stream/{id}/stop", s.stopStream)
+	mux.HandleFunc("GET /dictionaries", s.listDictionaries)
+	mux.HandleFunc("GET /words", s.listWords)
+	return mux
+}
+
+// solveRequest is the JSON body of a solve request.
+type solveRequest struct {
+	// Grid is the list of rows of the grid, e.g. ["AB#", "..."].
+	Grid []string `json:"grid"`
+	// Dictionary is the name of the dictionary, "ukacd" by default.
+	Dictionary string `json:"dictionary"`
+	// Solver is the name of the solver backend, "logicng" by default.
+	Solver string `json:"solver"`
+	// Count is the desired number of solutions: At least 1 for POST /solve, 1 by default; For POST /solve/stream, 0
+	// means no limit, which is the default.
+	Count int `json:"count"`
+	// Timeout is the maximal duration of the search, e.g. "30s"; Empty means no limit.
+	Timeout string `json:"timeout"`
+}
+
+// errorResponse is the JSON body of an error response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) solve(w http.ResponseWriter, r *http.Request) {
+	request := solveRequest{Dictionary: defaultDictionary, Solver: defaultSolver, Count: defaultCount}
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+	if request.Count < 1 {
+		// Solutions are collected in memory, they must be limited
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid count: %d", request.Count))
+		return
+	}
+
+	// Context is cancelled when the client disconnects or when the timeout expires
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	if err := s.acquireSolve(ctx); err != nil {
+		return
+	}
+	defer s.releaseSolve()
+	crossword, newSolver, timeout, err := s.prepare(&request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	result := collect(ctx, cancel, crossword, newSolver, request.Solver, request.Count, timeout)
+	if r.Context().Err() != nil {
+		// Client is gone
+		return
+	}
+	writeJson(w, http.StatusOK, result)
+}
+
+// decodeRequest decodes the body of the given HTTP request into the given solve request. It writes an error response
+// and returns false if the body is not a valid solve request or is larger than maxRequestSize.
+func decodeRequest(w http.ResponseWriter, r *http.Request, request *solveRequest) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(request)
+	if err == nil {
+		return true
+	}
+	statusCode := http.StatusBadRequest
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		statusCode = http.StatusRequestEntityTooLarge
+	}
+	writeError(w, statusCode, fmt.Errorf("invalid request: %w", err))
+	return false
+}
+
+// prepare validates the given request and returns the corresponding crossword, solver factory and timeout.
+func (s *Server) prepare(request *solveRequest) (*crogo.Crossword, solver.Factory, time.Duration, error) {
+	if request.Count < 0 {
+		return nil, nil, 0, fmt.Errorf("invalid count: %d", request.Count)
+	}
+	var timeout time.Duration
+	if request.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(request.Timeout); err != nil {
+			return nil, nil, 0, fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+	dictionary, err := s.dictionary(request.Dictionary)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	newSolver, err := solver.NewFactory(request.Solver)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	cells := make([][]rune, len(request.Grid))
+	for i, row := range request.Grid {
+		cells[i] = []rune(row)
+	}
+	crossword, err := crogo.NewCrossword(cells, dictionary.words())
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("invalid crossword: %w", err)
+	}
+	return crossword, newSolver, timeout, nil
+}
+
+// acquireSolve waits until a solve can be started or the given context is done.
+func (s *Server) acquireSolve(ctx context.Context) error {
+	select {
+	case s.solves <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("solve not started: %w", ctx.Err())
+	}
+}
+
+// releaseSolve signals the end of a solve.
+func (s *Server) releaseSolve() {
+	<-s.solves
+}
+
+// collect solves the given crossword with solvers created by the given factory until the desired count is reached, the solutions are
+// exhausted or the timeout expires. Timeout is measured from the start of the search, it is enforced using the given
+// context cancel function.
+func collect(ctx context.Context, cancel context.CancelFunc, crossword *crogo.Crossword,
+	newSolver solver.Factory, solverName string, count int, timeout time.Duration) api.Result {
+	solutions := crossword.SolveWithFactory(ctx, newSolver)
+	start := time.Now()
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, cancel)
+		defer timer.Stop()
+	}
+	result := api.Result{Solutions: []api.Solution{}, End: api.End{Status: api.StatusExhausted}}
+	for solution := range solutions {
+		result.Solutions = append(result.Solutions, api.SolutionFrom(crossword.Grid(), nil, solution,
+			len(result.Solutions), solverName, time.Since(start)))
+		if len(result.Solutions) == count {
+			result.Status = api.StatusCountReached
+			break
+		}
+	}
+	if result.Status == api.StatusExhausted && ctx.Err() != nil {
+		result.Status = api.StatusTimedOut
+	}
+	result.Count = len(result.Solutions)
+	result.ElapsedMs = time.Since(start).Milliseconds()
+	return result
+}
+
+// dictionaryResponse is the JSON representation of a dictionary.
+type dictionaryResponse struct {
+	Name      string `json:"name"`
+	WordCount int    `json:"wordCount"`
+}
+
+func (s *Server) listDictionaries(w http.ResponseWriter, _ *http.Request) {
+	response := make([]dictionaryResponse, len(s.dictionaries))
+	for i, dictionary := range s.dictionaries {
+		response[i] = dictionaryResponse{dictionary.Name, len(dictionary.words())}
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+// wordsResponse is the JSON body of a words response.
+type wordsResponse struct {
+	Words []string `json:"words"`
+	// Truncated indicates whether more words match the pattern than the limit.
+	Truncated bool `json:"truncated"`
+}
+
+func (s *Server) listWords(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	pattern := []rune(strings.ToUpper(query.Get("pattern")))
+	if len(pattern) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("missing pattern"))
+		return
+	}
+	name := query.Get("dictionary")
+	if name == "" {
+		name = defaultDictionary
+	}
+	dictionary, err := s.dictionary(name)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit := defaultWordLimit
+	if query.Has("limit") {
+		if limit, err = strconv.Atoi(query.Get("limit")); err != nil || limit < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit: %s", query.Get("limit")))
+			return
+		}
+	}
+	response := wordsResponse{Words: []string{}}
+	for _, word := range dictionary.words() {
+		if !grid.Matches(word, pattern) {
+			continue
+		}
+		if len(response.Words) == limit {
+			response.Truncated = true
+			break
+		}
+		response.Words = append(response.Words, word)
+	}
+	writeJson(w, http.StatusOK, response)
+}
+
+// dictionary returns the dictionary with the given name.
+func (s *Server) dictionary(name string) (*Dictionary, error) {
+	index := slices.IndexFunc(s.dictionaries, func(d *Dictionary) bool { return d.Name == name })
+	if index < 0 {
+		return nil, fmt.Errorf("unknown dictionary: %s", name)
+	}
+	return s.dictionaries[index], nil
+}
+
+// writeJson writes the given value as JSON response with the given status code.
+func writeJson(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	// Nothing sensible can be done if the client cannot be written to
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes the given error as JSON response with the given status code.
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	writeJson(w, statusCode, errorResponse{err.Error()})
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"crogo/internal/api"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServer(maxSolves int) *Server {
+	return New([]*Dictionary{
+		NewDictionary("tiny", func() []string { return []string{"AB", "BA", "AA", "BB", "ABA", "BAB"} }),
+	}, maxSolves)
+}
+
+func post(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(http.MethodPost, "/solve", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func get(t *testing.T, handler http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	return recorder
+}
+
+func TestSolve(t *testing.T) {
+	handler := newTestServer(1).Handler()
+
+	response := post(t, handler, `{"grid": ["A.", ".."], "dictionary": "tiny", "solver": "gini", "count": 10}`)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "application/json", response.Header().Get("Content-Type"))
+	var result api.Result
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+	assert.Equal(t, api.StatusExhausted, result.Status)
+	assert.Equal(t, 8, result.Count)
+	require.Len(t, result.Solutions, 8)
+	assert.Equal(t, "gini", result.Solutions[0].Solver)
+	assert.Equal(t, 'A', rune(result.Solutions[0].Rows[0][0]))
+}
+
+func TestSolve_CountReached(t *testing.T) {
+	handler := newTestServer(1).Handler()
+
+	response := post(t, handler, `{"grid": ["..", ".."], "dictionary": "tiny", "solver": "gini", "count": 2}`)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	var result api.Result
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+	assert.Equal(t, api.StatusCountReached, result.Status)
+	assert.Len(t, result.Solutions, 2)
+}
+
+func TestSolve_InvalidRequests(t *testing.T) {
+	handler := newTestServer(1).Handler()
+	testCases := map[string]string{
+		`{"grid": ["..", ".."], "dictionary": "unknown"}`:             `{"error":"unknown dictionary: unknown"}`,
+		`{"grid": ["..", ".."], "dictionary": "tiny", "solver": "x"}`: `{"error":"unknown solver: x"}`,
+		`{"grid": ["..", ".."], "dictionary": "tiny", "count": 0}`:    `{"error":"invalid count: 0"}`,
+		`{"grid": ["..", ".."], "timeout": "soon"}`:                   `{"error":"invalid timeout: time: invalid duration \"soon\""}`,
+		`{"grid": ["..", "@."], "dictionary": "tiny"}`:                `{"error":"invalid crossword: invalid value at row #1, column #0: @"}`,
+		`[]`: `{"error":"invalid request: json: cannot unmarshal array into Go value of type server.solveRequest"}`,
+	}
+	for body, expectedError := range testCases {
+		t.Run(body, func(t *testing.T) {
+			response := post(t, handler, body)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+			assert.JSONEq(t, expectedError, response.Body.String())
+		})
+	}
+}
+
+func TestSolve_TooLarge(t *testing.T) {
+	handler := newTestServer(1).Handler()
+	body := `{"grid": ["` + strings.Repeat(".", maxRequestSize) + `"]}`
+
+	response := post(t, handler, body)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.Code)
+	assert.JSONEq(t, `{"error":"invalid request: http: request body too large"}`, response.Body.String())
+}
+
+func TestSolve_ClientGone(t *testing.T) {
+	server := newTestServer(1)
+	server.solves <- struct{}{} // all solves in progress
+	ctx, cancel := context.WithCancel(context.Background())
+	request := httptest.NewRequestWithContext(ctx, http.MethodPost, "/solve",
+		strings.NewReader(`{"grid": ["..", ".."], "dictionary": "tiny", "solver": "gini"}`))
+	recorder := httptest.NewRecorder()
+	done := make(chan struct{})
+
+	go func() {
+		server.Handler().ServeHTTP(recorder, request)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+		assert.Empty(t, recorder.Body.String())
+	case <-time.After(5 * time.Second):
+		t.Fatal("request waiting for a solve slot was not abandoned")
+	}
+}
+
+func TestListDictionaries(t *testing.T) {
+	response := get(t, newTestServer(1).Handler(), "/dictionaries")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `[{"name":"tiny","wordCount":6}]`, response.Body.String())
+}
+
+func TestListWords(t *testing.T) {
+	handler := newTestServer(1).Handler()
+
+	response := get(t, handler, "/words?dictionary=tiny&pattern=a.")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"words":["AB","AA"],"truncated":false}`, response.Body.String())
+}
+
+func TestListWords_Limit(t *testing.T) {
+	handler := newTestServer(1).Handler()
+
+	response := get(t, handler, "/words?dictionary=tiny&pattern=..&limit=3")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"words":["AB","BA","AA"],"truncated":true}`, response.Body.String())
+}
+
+func TestListWords_MissingPattern(t *testing.T) {
+	response := get(t, newTestServer(1).Handler(), "/words?dictionary=tiny")
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.JSONEq(t, `{"error":"missing pattern"}`, response.Body.String())
+}
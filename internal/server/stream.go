@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"crogo/internal/api"
+	"crogo/pkg/crogo"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultHeartbeatInterval is the default interval between two heartbeat events of a stream.
+const defaultHeartbeatInterval = 5 * time.Second
+
+// Names of the server-sent events of a stream.
+const (
+	eventStarted   = "started"
+	eventSolution  = "solution"
+	eventHeartbeat = "heartbeat"
+	eventEnd       = "end"
+)
+
+// startedEvent is the data of the first event of a stream, giving the identifier to use to stop it.
+type startedEvent struct {
+	Id string `json:"id"`
+}
+
+// heartbeatEvent is the data of a heartbeat event, sent periodically while the search goes on.
+type heartbeatEvent struct {
+	Count     int   `json:"count"`
+	ElapsedMs int64 `json:"elapsedMs"`
+}
+
+// solveStream solves the grid given in the request body like solve but streams the solutions as server-sent events as
+// soon as they are found: A "started" event gives the stream identifier, then "solution" events are interleaved with
+// "heartbeat" events until the "end" event.
+//
+// Enumeration stops when the count is reached, the solutions are exhausted, the timeout expires, the client
+// disconnects or the client requests it using the stop endpoint.
+func (s *Server) solveStream(w http.ResponseWriter, r *http.Request) {
+	request := solveRequest{Dictionary: defaultDictionary, Solver: defaultSolver}
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	id, stopRequested := s.registerStream(cancel)
+	defer s.unregisterStream(id)
+	if err := s.acquireSolve(ctx); err != nil {
+		return
+	}
+	defer s.releaseSolve()
+	crossword, newSolver, timeout, err := s.prepare(&request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	events := &eventWriter{w, flusher, nil}
+	events.write(eventStarted, startedEvent{id})
+
+	start := time.Now()
+	solutions := pull(ctx, crossword.SolveWithFactory(ctx, newSolver))
+	var timeoutExpired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutExpired = timer.C
+	}
+	heartbeat := time.NewTicker(s.heartbeatInterval)
+	defer heartbeat.Stop()
+	count := 0
+	status := api.StatusExhausted
+loop:
+	for events.err == nil {
+		select {
+		case solution, found := <-solutions:
+			if !found {
+				break loop
+			}
+			events.write(eventSolution, api.SolutionFrom(crossword.Grid(), nil, solution, count,
+				request.Solver, time.Since(start)))
+			count++
+			if count == request.Count {
+				status = api.StatusCountReached
+				break loop
+			}
+		case <-heartbeat.C:
+			events.write(eventHeartbeat, heartbeatEvent{count, time.Since(start).Milliseconds()})
+		case <-timeoutExpired:
+			status = api.StatusTimedOut
+			break loop
+		case <-stopRequested:
+			status = api.StatusStopped
+			break loop
+		case <-r.Context().Done():
+			// Client is gone
+			return
+		}
+	}
+	// Stops the search in progress, if any
+	cancel()
+	if status == api.StatusExhausted {
+		// Solutions may have ended because of a stop request
+		select {
+		case <-stopRequested:
+			status = api.StatusStopped
+		default:
+		}
+	}
+	events.write(eventEnd, api.End{Status: status, Count: count, ElapsedMs: time.Since(start).Milliseconds()})
+}
+
+// pull iterates over the given solutions in a new goroutine, using iter.Pull, and sends them to the returned channel,
+// which is closed once the solutions are exhausted or the given context is done. Pull iterator is stopped in both
+// cases: Cancelling the context stops the enumeration, including the search in progress.
+func pull(ctx context.Context, solutions crogo.Solutions) <-chan [][]rune {
+	channel := make(chan [][]rune)
+	go func() {
+		defer close(channel)
+		next, stop := iter.Pull(solutions)
+		defer stop()
+		for {
+			solution, found := next()
+			if !found {
+				return
+			}
+			select {
+			case channel <- solution:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return channel
+}
+
+// stopStream stops the stream whose identifier is given in the path.
+func (s *Server) stopStream(w http.ResponseWriter, r *http.Request) {
+	s.streamsMutex.Lock()
+	stream, found := s.streams[r.PathValue("id")]
+	s.streamsMutex.Unlock()
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown stream: %s", r.PathValue("id")))
+		return
+	}
+	stream.stop()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// stream is a running stream.
+type stream struct {
+	// stopRequested is closed when the client requests the stream to stop.
+	stopRequested chan struct{}
+	// stop requests the stream to stop, it may be called several times.
+	stop func()
+}
+
+// registerStream registers a new stream and returns its identifier and the channel closed when the client requests
+// the stream to stop. Given cancel function is called when the stop is requested.
+func (s *Server) registerStream(cancel context.CancelFunc) (string, <-chan struct{}) {
+	idBytes := make([]byte, 8)
+	_, _ = rand.Read(idBytes) // never returns an error
+	id := hex.EncodeToString(idBytes)
+	stopRequested := make(chan struct{})
+	s.streamsMutex.Lock()
+	defer s.streamsMutex.Unlock()
+	s.streams[id] = &stream{stopRequested, sync.OnceFunc(func() {
+		close(stopRequested)
+		cancel()
+	})}
+	return id, stopRequested
+}
+
+// unregisterStream unregisters the stream with the given identifier.
+func (s *Server) unregisterStream(id string) {
+	s.streamsMutex.Lock()
+	defer s.streamsMutex.Unlock()
+	delete(s.streams, id)
+}
+
+// eventWriter writes server-sent events. Once an error occurred, further writes are ignored.
+type eventWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	err     error
+}
+
+// write writes the given data as JSON in an event of the given name.
+func (e *eventWriter) write(name string, data any) {
+	if e.err != nil {
+		return
+	}
+	encodedData, err := json.Marshal(data)
+	if err != nil {
+		e.err = err
+		return
+	}
+	if _, e.err = fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", name, encodedData); e.err == nil {
+		e.flusher.Flush()
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"bufio"
+	"crogo/internal/api"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// event is a server-sent event.
+type event struct {
+	name string
+	data string
+}
+
+// readEvent reads the next event of the given stream.
+func readEvent(t *testing.T, reader *bufio.Reader) event {
+	t.Helper()
+	var e event
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return e
+		case strings.HasPrefix(line, "event: "):
+			e.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+// readEnd reads the events of the given stream until the end event, and returns the number of events read by name
+// and the outcome of the search.
+func readEnd(t *testing.T, reader *bufio.Reader) (map[string]int, api.End) {
+	t.Helper()
+	counts := make(map[string]int)
+	for {
+		e := readEvent(t, reader)
+		counts[e.name]++
+		if e.name == eventEnd {
+			var end api.End
+			require.NoError(t, json.Unmarshal([]byte(e.data), &end))
+			return counts, end
+		}
+	}
+}
+
+// binaryDictionary returns the words of the given length made of A and B.
+func binaryDictionary(length int) []string {
+	words := []string{""}
+	for range length {
+		var longerWords []string
+		for _, word := range words {
+			longerWords = append(longerWords, word+"A", word+"B")
+		}
+		words = longerWords
+	}
+	return words
+}
+
+func newStreamTestServer() *Server {
+	return New([]*Dictionary{
+		NewDictionary("tiny", func() []string { return []string{"AB", "BA", "AA", "BB", "ABA", "BAB"} }),
+		NewDictionary("binary", func() []string { return binaryDictionary(4) }),
+	}, 1)
+}
+
+func TestSolveStream(t *testing.T) {
+	handler := newStreamTestServer().Handler()
+	request := httptest.NewRequest(http.MethodPost, "/solve/stream",
+		strings.NewReader(`{"grid": ["A.", ".."], "dictionary": "tiny", "solver": "gini"}`))
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	reader := bufio.NewReader(recorder.Body)
+	assert.Equal(t, eventStarted, readEvent(t, reader).name)
+	first := readEvent(t, reader)
+	assert.Equal(t, eventSolution, first.name)
+	var solution api.Solution
+	require.NoError(t, json.Unmarshal([]byte(first.data), &solution))
+	assert.Equal(t, 0, solution.Index)
+	counts, end := readEnd(t, reader)
+	assert.Equal(t, 7, counts[eventSolution])
+	assert.Equal(t, api.End{Status: api.StatusExhausted, Count: 8, ElapsedMs: end.ElapsedMs}, end)
+}
+
+func TestSolveStream_Heartbeat(t *testing.T) {
+	server := newStreamTestServer()
+	server.heartbeatInterval = time.Millisecond
+	request := httptest.NewRequest(http.MethodPost, "/solve/stream",
+		strings.NewReader(`{"grid": ["....", "....", "....", "...."], "dictionary": "binary", "solver": "gini",
+"count": 300}`))
+	recorder := httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(recorder, request)
+
+	counts, end := readEnd(t, bufio.NewReader(recorder.Body))
+	assert.Equal(t, 300, counts[eventSolution])
+	assert.Positive(t, counts[eventHeartbeat])
+	assert.Equal(t, api.StatusCountReached, end.Status)
+}
+
+func TestSolveStream_Stop(t *testing.T) {
+	httpServer := httptest.NewServer(newStreamTestServer().Handler())
+	defer httpServer.Close()
+	response, err := http.Post(httpServer.URL+"/solve/stream", "application/json",
+		strings.NewReader(`{"grid": ["....", "....", "....", "...."], "dictionary": "binary", "solver": "gini"}`))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	reader := bufio.NewReader(response.Body)
+	started := readEvent(t, reader)
+	require.Equal(t, eventStarted, started.name)
+	var startedData startedEvent
+	require.NoError(t, json.Unmarshal([]byte(started.data), &startedData))
+
+	stopResponse, err := http.Post(httpServer.URL+"/solve/stream/"+startedData.Id+"/stop", "", nil)
+	require.NoError(t, err)
+	_ = stopResponse.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, stopResponse.StatusCode)
+	counts, end := readEnd(t, reader)
+	assert.Equal(t, api.StatusStopped, end.Status)
+	assert.Less(t, counts[eventSolution], 1<<16)
+	_, err = reader.ReadByte()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestSolveStream_InvalidCount(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	newStreamTestServer().Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/solve/stream",
+		strings.NewReader(`{"grid": ["..", ".."], "dictionary": "tiny", "count": -1}`)))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.JSONEq(t, `{"error":"invalid count: -1"}`, recorder.Body.String())
+}
+
+func TestStopStream_Unknown(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	newStreamTestServer().Handler().ServeHTTP(recorder,
+		httptest.NewRequest(http.MethodPost, "/solve/stream/42/stop", nil))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.JSONEq(t, `{"error":"unknown stream: 42"}`, recorder.Body.String())
+}
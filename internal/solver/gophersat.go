@@ -1,54 +1,137 @@
 package solver
 
 import (
+	"context"
 	spi "crogo/pkg/solver"
 	sat "github.com/crillab/gophersat/solver"
 	"iter"
+	"slices"
 )
 
+// gophersatSolver is a spi.IncrementalSolver based on Gophersat.
+//
+// Gophersat cannot remove clauses nor reliably solve under assumptions, so clauses are recorded and replayed into a
+// new Gophersat solver for each iteration, along with the assumptions as unit clauses.
 type gophersatSolver struct {
 	*spi.BaseConfigurer
-	satSolver *sat.Solver
+	variableCount     int
+	relevantVariables []spi.Variable
+	clauses           [][]int
+	// scopes are the numbers of clauses when the open scopes were opened by Push.
+	scopes []int
 }
 
-// NewGophersatSolver creates a new instance of a spi.ConfigurableSolver based on Gophersat.
+// NewGophersatSolver creates a new instance of a spi.ConfigurableSolver based on Gophersat. Returned solver is also a
+// spi.IncrementalSolver.
 func NewGophersatSolver() spi.ConfigurableSolver {
-	problem := sat.Problem{}
-	satSolver := sat.New(&problem)
 	baseConfigurer := spi.BaseConfigurer{}
-	solverConfigurer := gophersatSolver{BaseConfigurer: &baseConfigurer, satSolver: satSolver}
+	solverConfigurer := gophersatSolver{BaseConfigurer: &baseConfigurer}
 	baseConfigurer.Configurer = &solverConfigurer
 	return &solverConfigurer
 }
 
+func (s *gophersatSolver) AllocateVariables(variableCount uint) {
+	s.variableCount = max(s.variableCount, int(variableCount))
+}
+
+func (s *gophersatSolver) SetRelevantVariables(variables []spi.Variable) {
+	s.relevantVariables = slices.Clone(variables)
+}
+
 func (s *gophersatSolver) AddClause(spiLiterals []spi.Literal) {
-	literals := gophersatLitsFrom(spiLiterals...)
-	clause := sat.NewClause(literals)
-	s.satSolver.AppendClause(clause)
+	clause := make([]int, len(spiLiterals))
+	for i, spiLiteral := range spiLiterals {
+		clause[i] = int(spiLiteral)
+		s.variableCount = max(s.variableCount, int(spi.VariableFrom(spiLiteral)))
+	}
+	s.clauses = append(s.clauses, clause)
 }
 
 // TODO override addExactlyOneClause with PB clause
 
-func gophersatLitsFrom(vals ...spi.Literal) []sat.Lit {
-	// That's a useless copy...
-	res := make([]sat.Lit, len(vals))
-	for i, val := range vals {
-		res[i] = sat.IntToLit(int32(val))
+func (s *gophersatSolver) Solutions() iter.Seq[spi.Model] {
+	return s.solutions(context.Background(), nil)
+}
+
+func (s *gophersatSolver) SolveUnder(ctx context.Context, assumptions []spi.Literal) (spi.Model, error) {
+	for model := range s.SolutionsUnder(ctx, assumptions) {
+		return model, nil
 	}
-	return res
+	return nil, ctx.Err()
 }
 
-func (s *gophersatSolver) Solutions() iter.Seq[spi.Model] {
+func (s *gophersatSolver) SolutionsUnder(ctx context.Context, assumptions []spi.Literal) iter.Seq[spi.Model] {
+	return func(yield func(spi.Model) bool) {
+		s.Push()
+		defer s.Pop()
+		s.solutions(ctx, assumptions)(yield)
+	}
+}
+
+func (s *gophersatSolver) Push() {
+	s.scopes = append(s.scopes, len(s.clauses))
+}
+
+func (s *gophersatSolver) Pop() {
+	s.clauses = s.clauses[:s.scopes[len(s.scopes)-1]]
+	s.scopes = s.scopes[:len(s.scopes)-1]
+}
+
+// solutions returns an iterator on the solutions satisfying the given assumptions. The clauses blocking the solutions
+// found are recorded as well, in the current scope.
+func (s *gophersatSolver) solutions(ctx context.Context, assumptions []spi.Literal) iter.Seq[spi.Model] {
 	return func(yield func(spi.Model) bool) {
-		models := make(chan []bool)
-		stop := make(chan struct{}, 1) // FIXME Enumerate does not read from stop channel?
-		defer close(stop)
-		go s.satSolver.Enumerate(models, stop)
-		for model := range models {
-			if keepGoing := yield(model); !keepGoing {
-				stop <- struct{}{}
+		cnf := slices.Clone(s.clauses)
+		for _, assumption := range assumptions {
+			cnf = append(cnf, []int{int(assumption)})
+		}
+		satSolver := sat.New(sat.ParseSliceNb(cnf, s.variableCount))
+		for ctx.Err() == nil && satSolver.Solve() == sat.Sat {
+			model := s.relevantModelFrom(satSolver.Model())
+			if keepGoing := yield(model); !keepGoing || ctx.Err() != nil {
 				break
 			}
+			differentModel := s.differentModelClause(model)
+			s.clauses = append(s.clauses, differentModel)
+			satSolver.AppendClause(sat.NewClause(gophersatLitsFrom(differentModel...)))
 		}
 	}
 }
+
+// relevantModelFrom returns the state of the relevant variables in the given full model, or the full model if no
+// relevant variables are set.
+func (s *gophersatSolver) relevantModelFrom(fullModel []bool) spi.Model {
+	if len(s.relevantVariables) == 0 {
+		return fullModel
+	}
+	model := make(spi.Model, len(s.relevantVariables))
+	for i, variable := range s.relevantVariables {
+		model[i] = fullModel[variable-1]
+	}
+	return model
+}
+
+// differentModelClause returns the clause blocking the given model.
+func (s *gophersatSolver) differentModelClause(model spi.Model) []int {
+	clause := make([]int, len(model))
+	for i, isPos := range model {
+		variable := i + 1
+		if len(s.relevantVariables) > 0 {
+			variable = int(s.relevantVariables[i])
+		}
+		if isPos {
+			clause[i] = -variable
+		} else {
+			clause[i] = variable
+		}
+	}
+	return clause
+}
+
+func gophersatLitsFrom(vals ...int) []sat.Lit {
+	res := make([]sat.Lit, len(vals))
+	for i, val := range vals {
+		res[i] = sat.IntToLit(int32(val))
+	}
+	return res
+}
@@ -0,0 +1,71 @@
+package solver
+
+import (
+	"context"
+	spi "crogo/pkg/solver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestingGophersatSolver() spi.IncrementalSolver {
+	s := NewGophersatSolver()
+	s.SetRelevantVariables([]spi.Variable{1, 2})
+	s.AddClause([]spi.Literal{1, 2})
+	s.AddClause([]spi.Literal{-3})
+	return s.(spi.IncrementalSolver)
+}
+
+func TestGophersatSolutions(t *testing.T) {
+	s := newTestingGophersatSolver()
+
+	var models []spi.Model
+	for model := range s.Solutions() {
+		models = append(models, model)
+	}
+	assert.ElementsMatch(t, []spi.Model{{false, true}, {true, false}, {true, true}}, models)
+
+	// Blocking clauses added by Solutions are permanent
+	for range s.Solutions() {
+		assert.Fail(t, "unexpected solution")
+	}
+}
+
+func TestGophersatSolutionsUnder(t *testing.T) {
+	s := newTestingGophersatSolver()
+
+	var models []spi.Model
+	for model := range s.SolutionsUnder(context.Background(), []spi.Literal{-1}) {
+		models = append(models, model)
+	}
+	assert.Equal(t, []spi.Model{{false, true}}, models)
+
+	models = nil
+	for model := range s.SolutionsUnder(context.Background(), nil) {
+		models = append(models, model)
+	}
+	assert.ElementsMatch(t, []spi.Model{{false, true}, {true, false}, {true, true}}, models)
+}
+
+func TestGophersatPushPop(t *testing.T) {
+	s := newTestingGophersatSolver()
+	configurer := s.(spi.Configurer)
+
+	s.Push()
+	configurer.AddClause([]spi.Literal{-1})
+	s.Push()
+	configurer.AddClause([]spi.Literal{-2})
+	model, err := s.SolveUnder(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Nil(t, model)
+
+	s.Pop()
+	model, err = s.SolveUnder(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, spi.Model{false, true}, model)
+
+	s.Pop()
+	model, err = s.SolveUnder(context.Background(), []spi.Literal{-2})
+	require.NoError(t, err)
+	assert.Equal(t, spi.Model{true, false}, model)
+}
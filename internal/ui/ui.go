@@ -0,0 +1,19 @@
+// Package ui provides the browser-based grid editor, a single page talking to the solving service of package server.
+package ui
+
+import (
+	"embed"
+	"net/http"
+)
+
+//go:embed index.html
+var files embed.FS
+
+// Handler returns the handler serving the editor page at "/" and delegating the other requests to the given handler
+// of the solving service.
+func Handler(api http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /{$}", http.FileServerFS(files))
+	mux.Handle("/", api)
+	return mux
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("api " + r.URL.Path))
+	})
+	handler := Handler(api)
+
+	page := httptest.NewRecorder()
+	handler.ServeHTTP(page, httptest.NewRequest(http.MethodGet, "/", nil))
+	delegated := httptest.NewRecorder()
+	handler.ServeHTTP(delegated, httptest.NewRequest(http.MethodGet, "/dictionaries", nil))
+
+	assert.Equal(t, http.StatusOK, page.Code)
+	assert.Equal(t, "text/html; charset=utf-8", page.Header().Get("Content-Type"))
+	assert.Contains(t, page.Body.String(), "<title>Crogo</title>")
+	assert.Equal(t, "api /dictionaries", delegated.Body.String())
+}
@@ -10,18 +10,56 @@ package variables
 
 import (
 	"crogo/internal/alphabet"
-	"crogo/internal/grid"
+	"crogo/pkg/grid"
 	"crogo/pkg/solver"
+	"math/rand/v2"
 )
 
 type Variables struct {
 	grid      *grid.Grid
 	wordCount int
+	// wordNumberings, if not nil, are the numberings of the words of each slot, see WithSeed.
+	wordNumberings []wordNumbering
+}
+
+// wordNumbering is a permutation of the word indexes, mapping a word index i to (factor*i + offset) modulo the word
+// count. factor is coprime with the word count.
+type wordNumbering struct {
+	factor int
+	offset int
 }
 
 // NewVariables constructs a new instance of Variables.
 func NewVariables(grid *grid.Grid, wordCount int) *Variables {
-	return &Variables{grid, wordCount}
+	return &Variables{grid, wordCount, nil}
+}
+
+// WithSeed returns a copy of these variables whose slot variables are numbered in a different order for each slot,
+// shuffled using the given seed. Since solvers explore the variables depending on their numbers, the solutions are
+// found in a different order, which is the same for the same seed.
+func (v *Variables) WithSeed(seed uint64) *Variables {
+	wordNumberings := make([]wordNumbering, v.grid.SlotCount())
+	for slotIndex := range wordNumberings {
+		wordNumberings[slotIndex] = wordNumbering{factor: 1}
+		if v.wordCount < 2 {
+			continue
+		}
+		random := rand.New(rand.NewPCG(seed, uint64(slotIndex)))
+		factor := 1 + random.IntN(v.wordCount-1)
+		for gcd(factor, v.wordCount) != 1 {
+			factor = 1 + random.IntN(v.wordCount-1)
+		}
+		wordNumberings[slotIndex] = wordNumbering{factor, random.IntN(v.wordCount)}
+	}
+	return &Variables{v.grid, v.wordCount, wordNumberings}
+}
+
+// gcd returns the greatest common divisor of the given positive integers.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
 }
 
 // CellValueCount returns the number of values that a cell of a solved grid can take.
@@ -59,8 +97,13 @@ func (v *Variables) RepresentingCells() []solver.Variable {
 // RepresentingSlot returns the variable associated to the given word at the given slot.
 //
 // RepresentingSlot variables are put after cell variables, so first slot variable corresponds to the number of cell variables
-// plus 1 (because variables start at 1).
+// plus 1 (because variables start at 1). Words of a slot are numbered in the word list order, unless shuffled by
+// WithSeed.
 func (v *Variables) RepresentingSlot(slotIndex, wordIndex int) solver.Variable {
+	if v.wordNumberings != nil {
+		numbering := v.wordNumberings[slotIndex]
+		wordIndex = (numbering.factor*wordIndex + numbering.offset) % v.wordCount
+	}
 	return solver.Variable(v.RepresentingCellCount() + // last cell variable
 		slotIndex*v.wordCount +
 		wordIndex +
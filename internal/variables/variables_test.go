@@ -1,7 +1,7 @@
 package variables
 
 import (
-	. "crogo/internal/grid"
+	. "crogo/pkg/grid"
 	. "crogo/pkg/solver"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -44,6 +44,28 @@ func TestRepresentingSlot(t *testing.T) {
 	assert.Equal(t, Variable(600_243), variables.RepresentingSlot(5, 99_999))
 }
 
+func TestRepresentingSlot_WithSeed(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	})
+	variables := NewVariables(grid, 100).WithSeed(42)
+
+	for slotIndex := range grid.SlotCount() {
+		slotVariables := make(map[Variable]bool)
+		for wordIndex := range 100 {
+			slotVariable := variables.RepresentingSlot(slotIndex, wordIndex)
+			assert.Equal(t, slotVariable, variables.RepresentingSlot(slotIndex, wordIndex))
+			assert.GreaterOrEqual(t, slotVariable, Variable(244+slotIndex*100))
+			assert.Less(t, slotVariable, Variable(244+(slotIndex+1)*100))
+			slotVariables[slotVariable] = true
+		}
+		assert.Len(t, slotVariables, 100, "Slot variables shall be distinct")
+	}
+	assert.NotEqual(t, NewVariables(grid, 100).RepresentingSlot(0, 0), variables.RepresentingSlot(0, 0))
+}
+
 func TestRepresentingCellCount(t *testing.T) {
 	grid, _ := NewGrid([][]rune{
 		{'.', '.', '.'},
@@ -0,0 +1,186 @@
+// Package clues attaches clues from a local clue database to the words of crossword solutions.
+package clues
+
+import (
+	"bytes"
+	"crogo/pkg/dictionaries"
+	"crogo/pkg/grid"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// dateLayouts are the supported layouts of the date column.
+var dateLayouts = []string{time.DateOnly, time.RFC3339, "2006/01/02", "2006-01", "2006"}
+
+// Clue is a clue of a clue database.
+type Clue struct {
+	// Word is the word defined by the clue, as written in the database.
+	Word string
+	// Text is the clue itself.
+	Text string
+	// Source is the publication the clue comes from, if known.
+	Source string
+	// Date is the publication date of the clue, zero if unknown.
+	Date time.Time
+}
+
+// Database is a clue database, indexed by normalized word.
+type Database struct {
+	clues map[string][]Clue
+}
+
+// Load loads the given clue database, in CSV or TSV format.
+//
+// Each record contains the word, the clue, and optionally the source and the date - e.g. 2024-12-31 - in this order.
+// The format is TSV if the first line contains a tab, CSV otherwise. A first record starting with "word" is considered
+// as a header and ignored. Words are normalized the same way as dictionary words, so that "ice cream" clues ICECREAM.
+func Load(r io.Reader) (*Database, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read clue database: %w", err)
+	}
+	records, err := parseRecords(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clue database: %w", err)
+	}
+	database := &Database{clues: make(map[string][]Clue)}
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "word") {
+			continue
+		}
+		clue, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clue database: record %d: %w", i+1, err)
+		}
+		if word := dictionaries.Normalize(clue.Word); word != "" {
+			database.clues[word] = append(database.clues[word], clue)
+		}
+	}
+	return database, nil
+}
+
+// parseRecords splits the given CSV or TSV content into records. TSV fields are not quoted.
+func parseRecords(content []byte) ([][]string, error) {
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	if !bytes.ContainsRune(firstLine, '\t') {
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse csv: %w", err)
+		}
+		return records, nil
+	}
+	var records [][]string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line != "" {
+			records = append(records, strings.Split(line, "\t"))
+		}
+	}
+	return records, nil
+}
+
+// parseRecord parses the given record of the clue database.
+func parseRecord(record []string) (Clue, error) {
+	if len(record) < 2 {
+		return Clue{}, errors.New("missing clue")
+	}
+	clue := Clue{Word: strings.TrimSpace(record[0]), Text: strings.TrimSpace(record[1])}
+	if len(record) >= 3 {
+		clue.Source = strings.TrimSpace(record[2])
+	}
+	if len(record) >= 4 && strings.TrimSpace(record[3]) != "" {
+		date, err := parseDate(strings.TrimSpace(record[3]))
+		if err != nil {
+			return Clue{}, err
+		}
+		clue.Date = date
+	}
+	return clue, nil
+}
+
+// parseDate parses the given date using the first matching supported layout.
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %s", value)
+}
+
+// Strategy orders the candidate clues of a word in place, best first.
+type Strategy func(candidates []Clue)
+
+// MostRecent orders the candidate clues from the most recent to the oldest. Undated clues come last.
+func MostRecent(candidates []Clue) {
+	slices.SortStableFunc(candidates, func(a, b Clue) int { return b.Date.Compare(a.Date) })
+}
+
+// Shortest orders the candidate clues from the shortest to the longest.
+func Shortest(candidates []Clue) {
+	slices.SortStableFunc(candidates, func(a, b Clue) int {
+		return utf8.RuneCountInString(a.Text) - utf8.RuneCountInString(b.Text)
+	})
+}
+
+// Random returns a strategy shuffling the candidate clues using the given source of randomness.
+func Random(random *rand.Rand) Strategy {
+	return func(candidates []Clue) {
+		random.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+	}
+}
+
+// Size returns the number of words clued by the database.
+func (d *Database) Size() int {
+	return len(d.clues)
+}
+
+// Candidates returns at most count candidate clues of the given word, ordered by the given strategy. A negative count
+// means no limit.
+func (d *Database) Candidates(word string, strategy Strategy, count int) []Clue {
+	candidates := slices.Clone(d.clues[dictionaries.Normalize(word)])
+	strategy(candidates)
+	if count >= 0 && count < len(candidates) {
+		candidates = candidates[:count]
+	}
+	return candidates
+}
+
+// CluedEntry is an entry of a solution with its candidate clues, best first.
+type CluedEntry struct {
+	grid.Entry
+	// Candidates are the candidate clues of the entry, possibly empty.
+	Candidates []Clue
+}
+
+// Attach returns the given entries with at most count candidate clues each, ordered by the given strategy.
+func (d *Database) Attach(entries []grid.Entry, strategy Strategy, count int) []CluedEntry {
+	cluedEntries := make([]CluedEntry, len(entries))
+	for i, entry := range entries {
+		cluedEntries[i] = CluedEntry{entry, d.Candidates(entry.Word(), strategy, count)}
+	}
+	return cluedEntries
+}
+
+// ClueOf returns a clue function - as expected by the writers of the formats packages - giving the best candidate
+// clue of an entry according to the given strategy, or an empty string if the word is not in the database.
+func (d *Database) ClueOf(strategy Strategy) func(grid.Entry) string {
+	return func(entry grid.Entry) string {
+		candidates := d.Candidates(entry.Word(), strategy, 1)
+		if len(candidates) == 0 {
+			return ""
+		}
+		return candidates[0].Text
+	}
+}
@@ -0,0 +1,122 @@
+package clues
+
+import (
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadSample(t *testing.T, path string) *Database {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	database, err := Load(file)
+	require.NoError(t, err)
+	return database
+}
+
+func texts(clues []Clue) []string {
+	result := make([]string, len(clues))
+	for i, clue := range clues {
+		result[i] = clue.Text
+	}
+	return result
+}
+
+func TestLoad_Csv(t *testing.T) {
+	database := loadSample(t, "testdata/sample.csv")
+
+	assert.Equal(t, 3, database.Size())
+	expectedClue := Clue{"AB", "Sailor, briefly", "Daily Planet", time.Date(2023, 11, 20, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, expectedClue, database.clues["AB"][1])
+	assert.Equal(t, []string{"Cold dessert"}, texts(database.clues["ICECREAM"]))
+}
+
+func TestLoad_Tsv(t *testing.T) {
+	database := loadSample(t, "testdata/sample.tsv")
+
+	assert.Equal(t, []string{`"Quoted" clue`}, texts(database.clues["AB"]))
+	assert.Equal(t, []string{"Alphabet run"}, texts(database.clues["CDE"]))
+}
+
+func TestLoad_InvalidDate(t *testing.T) {
+	_, err := Load(strings.NewReader("ab,Clue,Source,yesterday\n"))
+
+	assert.EqualError(t, err, "invalid clue database: record 1: invalid date yesterday")
+}
+
+func TestLoad_MissingClue(t *testing.T) {
+	_, err := Load(strings.NewReader("ab,Clue\ncd\n"))
+
+	assert.EqualError(t, err, "invalid clue database: record 2: missing clue")
+}
+
+func TestCandidates_MostRecent(t *testing.T) {
+	database := loadSample(t, "testdata/sample.csv")
+
+	candidates := database.Candidates("ab", MostRecent, -1)
+
+	assert.Equal(t, []string{"Sailor, briefly", "Muscles for a six-pack", "Abdominal"}, texts(candidates))
+}
+
+func TestCandidates_Shortest(t *testing.T) {
+	database := loadSample(t, "testdata/sample.csv")
+
+	candidates := database.Candidates("AB", Shortest, 2)
+
+	assert.Equal(t, []string{"Abdominal", "Sailor, briefly"}, texts(candidates))
+}
+
+func TestCandidates_Random(t *testing.T) {
+	database := loadSample(t, "testdata/sample.csv")
+
+	first := database.Candidates("AB", Random(rand.New(rand.NewPCG(1, 2))), -1)
+	second := database.Candidates("AB", Random(rand.New(rand.NewPCG(1, 2))), -1)
+
+	assert.ElementsMatch(t, []string{"Sailor, briefly", "Muscles for a six-pack", "Abdominal"}, texts(first))
+	assert.Equal(t, first, second)
+}
+
+func TestCandidates_Unknown(t *testing.T) {
+	database := loadSample(t, "testdata/sample.csv")
+
+	assert.Empty(t, database.Candidates("XYZ", Shortest, 1))
+}
+
+func TestAttach(t *testing.T) {
+	database := loadSample(t, "testdata/sample.csv")
+	g, err := grid.NewGrid([][]rune{
+		{'.', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	entries := g.Entries([][]rune{{'A', 'B', '#'}, {'C', 'D', 'E'}})
+
+	cluedEntries := database.Attach(entries, Shortest, 1)
+
+	require.Len(t, cluedEntries, 4)
+	assert.Equal(t, "AB", cluedEntries[0].Word())
+	assert.Equal(t, []string{"Abdominal"}, texts(cluedEntries[0].Candidates))
+	assert.Equal(t, "CDE", cluedEntries[1].Word())
+	assert.Equal(t, []string{"Letters after B"}, texts(cluedEntries[1].Candidates))
+	assert.Empty(t, cluedEntries[2].Candidates)
+}
+
+func TestClueOf(t *testing.T) {
+	database := loadSample(t, "testdata/sample.csv")
+	g, err := grid.NewGrid([][]rune{
+		{'.', '.'},
+		{'.', '.'},
+	})
+	require.NoError(t, err)
+	entries := g.Entries([][]rune{{'A', 'B'}, {'X', 'Y'}})
+	clueOf := database.ClueOf(MostRecent)
+
+	assert.Equal(t, "Sailor, briefly", clueOf(entries[0]))
+	assert.Equal(t, "", clueOf(entries[1]))
+}
@@ -0,0 +1,65 @@
+package crogo
+
+import (
+	"context"
+	"crogo/internal/alphabet"
+	"crogo/pkg/grid"
+	"crogo/pkg/solver"
+	"errors"
+)
+
+// Backbone returns the letters of this crossword which are the same in all its fills, computed using builtin solver,
+// see BackboneWith.
+func (c *Crossword) Backbone() [][]rune {
+	backbone, _ := c.BackboneWith(context.Background(), solver.NewLogicNgSolver())
+	return backbone
+}
+
+// BackboneWith returns the letters of this crossword which are the same in all its fills, computed using the given
+// solver. Returned cells contain these letters, the blocks, and grid.CellEmpty elsewhere. Returned cells are nil if the
+// crossword has no fill.
+//
+// The given solver must be a new solver.IncrementalSolver: The crossword is encoded into it, then each letter of a
+// first fill is checked by searching for a fill without it. Function returns an error if the solver is not a
+// solver.IncrementalSolver, or the context error if the computation has been interrupted.
+func (c *Crossword) BackboneWith(ctx context.Context, configurableSolver solver.ConfigurableSolver) ([][]rune, error) {
+	incrementalSolver, ok := configurableSolver.(solver.IncrementalSolver)
+	if !ok {
+		return nil, errors.New("solver does not support incremental solving")
+	}
+	c.addClausesTo(configurableSolver)
+	model, err := incrementalSolver.SolveUnder(ctx, nil)
+	if err != nil || model == nil {
+		return nil, err
+	}
+	backbone := c.variables.BackToDomain(model)
+	for row, rowCells := range backbone {
+		for column, cell := range rowCells {
+			if cell == grid.CellBlock || cell == grid.CellEmpty {
+				// Block or letter already known to differ in another fill
+				continue
+			}
+			letterIndex, _ := alphabet.IndexOf(cell)
+			literal := solver.Literal(c.variables.RepresentingCell(row, column, letterIndex))
+			model, err = incrementalSolver.SolveUnder(ctx, []solver.Literal{literal.Negated()})
+			if err != nil {
+				return nil, err
+			}
+			if model != nil {
+				removeDifferentLetters(backbone, c.variables.BackToDomain(model))
+			}
+		}
+	}
+	return backbone, nil
+}
+
+// removeDifferentLetters empties the cells of the given backbone whose letters differ in the given fill.
+func removeDifferentLetters(backbone [][]rune, fill [][]rune) {
+	for row, rowCells := range backbone {
+		for column, cell := range rowCells {
+			if cell != fill[row][column] {
+				backbone[row][column] = grid.CellEmpty
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package crogo
+
+import (
+	"context"
+	internalsolver "crogo/internal/solver"
+	"crogo/pkg/grid"
+	"crogo/pkg/solver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBackbone(t *testing.T) {
+	words := []string{"AB", "CD", "AC", "BD"}
+	cells := [][]rune{
+		{'.', '.'},
+		{'.', 'D'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+
+	backbone := crossword.Backbone()
+
+	// Fills are AB,CD and AC,BD
+	expectedBackbone := [][]rune{
+		{'A', '.'},
+		{'.', 'D'},
+	}
+	assert.Equal(t, expectedBackbone, backbone)
+}
+
+func TestBackboneWith(t *testing.T) {
+	for name, newSolver := range map[string]solver.Factory{
+		"logicng":   solver.NewLogicNgSolver,
+		"gini":      solver.NewGiniSolver,
+		"gophersat": internalsolver.NewGophersatSolver,
+	} {
+		t.Run(name, func(t *testing.T) {
+			crossword := newTestingCrossword(t)
+
+			backbone, err := crossword.BackboneWith(context.Background(), newSolver())
+			require.NoError(t, err)
+
+			assert.Equal(t, commonLetters(crossword.Solve()), backbone)
+		})
+	}
+}
+
+func TestBackboneWith_Forced(t *testing.T) {
+	words := []string{"ABC", "DEF", "AD", "BE", "CF"}
+	cells := [][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+
+	backbone, err := crossword.BackboneWith(context.Background(), solver.NewGiniSolver())
+	require.NoError(t, err)
+
+	expectedBackbone := [][]rune{
+		{'A', 'B', 'C'},
+		{'D', 'E', 'F'},
+	}
+	assert.Equal(t, expectedBackbone, backbone)
+}
+
+func TestBackboneWith_NoFill(t *testing.T) {
+	crossword, err := NewCrossword([][]rune{{'.', '.'}}, []string{"AAA"})
+	require.NoError(t, err)
+
+	backbone, err := crossword.BackboneWith(context.Background(), solver.NewLogicNgSolver())
+	require.NoError(t, err)
+
+	assert.Nil(t, backbone)
+}
+
+func TestBackboneWith_Cancelled(t *testing.T) {
+	crossword := newTestingCrossword(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := crossword.BackboneWith(ctx, solver.NewLogicNgSolver())
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// commonLetters returns the letters common to all the given solutions, grid.CellEmpty elsewhere.
+func commonLetters(solutions Solutions) [][]rune {
+	var common [][]rune
+	for solution := range solutions {
+		if common == nil {
+			common = solution
+			continue
+		}
+		for row, rowCells := range common {
+			for column, cell := range rowCells {
+				if cell != solution[row][column] {
+					common[row][column] = grid.CellEmpty
+				}
+			}
+		}
+	}
+	return common
+}
@@ -0,0 +1,134 @@
+package crogo
+
+import (
+	"crogo/internal/alphabet"
+	"crogo/pkg/grid"
+)
+
+// Candidates are the letters and words which remain possible in a crossword once its constraints are propagated.
+//
+// Propagation is much cheaper than a search but incomplete: Candidates may contain letters and words which are not part
+// of any fill. Conversely, a cell without candidate letter proves that the crossword has no fill.
+type Candidates struct {
+	// Letters are the letters which remain possible in each cell, in alphabetical order. They are nil for blocks.
+	Letters [][][]rune
+	// WordCounts are the numbers of words which remain possible in each slot.
+	WordCounts map[grid.Slot]int
+}
+
+// letterSet is a set of letters of the alphabet, the bit i being set if the letter of index i is in the set.
+type letterSet uint32
+
+// Candidates propagates the constraints of this crossword and returns the letters and words which remain possible.
+//
+// Propagation removes the words which do not match the letters remaining possible in the cells of their slots, and the
+// letters which are not part of any word remaining possible in the slots of their cells, until nothing changes.
+func (c *Crossword) Candidates() Candidates {
+	letterSets := c.initialLetterSets()
+	slots := c.grid.Slots()
+	wordsBySlot := make([][]string, len(slots))
+	for i, slot := range slots {
+		for _, word := range c.words {
+			if len(word) == slot.Length() {
+				wordsBySlot[i] = append(wordsBySlot[i], word)
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for i, slot := range slots {
+			positions := slot.Positions()
+			wordsBySlot[i] = matchingWords(wordsBySlot[i], positions, letterSets)
+			for j, supported := range supportedLetterSets(wordsBySlot[i], len(positions)) {
+				letters := &letterSets[positions[j].Row()][positions[j].Column()]
+				if *letters&supported != *letters {
+					*letters &= supported
+					changed = true
+				}
+			}
+		}
+	}
+	candidates := Candidates{Letters: make([][][]rune, len(letterSets)), WordCounts: make(map[grid.Slot]int)}
+	for row, rowLetterSets := range letterSets {
+		candidates.Letters[row] = make([][]rune, len(rowLetterSets))
+		for column, letters := range rowLetterSets {
+			if c.grid.LetterAt(row, column) != grid.CellBlock {
+				candidates.Letters[row][column] = letters.runes()
+			}
+		}
+	}
+	for i, slot := range slots {
+		candidates.WordCounts[slot] = len(wordsBySlot[i])
+	}
+	return candidates
+}
+
+// initialLetterSets returns the letters possible in each cell of the input grid: All the letters for empty cells, the
+// prefilled letter for prefilled cells and none for blocks.
+func (c *Crossword) initialLetterSets() [][]letterSet {
+	allLetters := letterSet(1)<<alphabet.LetterCount() - 1
+	letterSets := make([][]letterSet, c.grid.RowCount())
+	for row := range letterSets {
+		letterSets[row] = make([]letterSet, c.grid.ColumnCount())
+		for column := range letterSets[row] {
+			switch letter := c.grid.LetterAt(row, column); letter {
+			case grid.CellBlock:
+				// No letter
+			case grid.CellEmpty:
+				letterSets[row][column] = allLetters
+			default:
+				letterIndex, _ := alphabet.IndexOf(letter)
+				letterSets[row][column] = 1 << letterIndex
+			}
+		}
+	}
+	return letterSets
+}
+
+// matchingWords returns the given words whose letters are possible in the cells at the given positions. The given words
+// slice is reused.
+func matchingWords(words []string, positions []grid.Pos, letterSets [][]letterSet) []string {
+	matching := words[:0]
+	for _, word := range words {
+		if wordMatches(word, positions, letterSets) {
+			matching = append(matching, word)
+		}
+	}
+	return matching
+}
+
+// wordMatches returns true iff the letters of the given word are possible in the cells at the given positions.
+func wordMatches(word string, positions []grid.Pos, letterSets [][]letterSet) bool {
+	for i, letter := range []rune(word) {
+		pos := positions[i]
+		letterIndex, _ := alphabet.IndexOf(letter)
+		if letterSets[pos.Row()][pos.Column()]&(1<<letterIndex) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// supportedLetterSets returns, for each of the given number of positions, the letters of the given words at this
+// position.
+func supportedLetterSets(words []string, length int) []letterSet {
+	supported := make([]letterSet, length)
+	for _, word := range words {
+		for i, letter := range []rune(word) {
+			letterIndex, _ := alphabet.IndexOf(letter)
+			supported[i] |= 1 << letterIndex
+		}
+	}
+	return supported
+}
+
+// runes returns the letters of this set, in alphabetical order.
+func (s letterSet) runes() []rune {
+	letters := make([]rune, 0, alphabet.LetterCount())
+	for letterIndex := range alphabet.LetterCount() {
+		if s&(1<<letterIndex) != 0 {
+			letters = append(letters, alphabet.LetterAt(letterIndex))
+		}
+	}
+	return letters
+}
@@ -0,0 +1,84 @@
+package crogo
+
+import (
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+)
+
+func TestCandidates(t *testing.T) {
+	words := []string{"ABC", "DEF", "ABF", "AD", "BE", "CF"}
+	cells := [][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+
+	candidates := crossword.Candidates()
+
+	expectedLetters := [][][]rune{
+		{{'A'}, {'B'}, {'C'}},
+		{{'D'}, {'E'}, {'F'}},
+	}
+	assert.Equal(t, expectedLetters, candidates.Letters)
+	expectedWordCounts := map[grid.Slot]int{
+		grid.NewAcrossSlot(0, 3, 0): 1,
+		grid.NewAcrossSlot(0, 3, 1): 1,
+		grid.NewDownSlot(0, 2, 0):   1,
+		grid.NewDownSlot(0, 2, 1):   1,
+		grid.NewDownSlot(0, 2, 2):   1,
+	}
+	assert.Equal(t, expectedWordCounts, candidates.WordCounts)
+}
+
+func TestCandidates_Blocks(t *testing.T) {
+	words := []string{"AB", "AC", "BD"}
+	cells := [][]rune{
+		{'.', '.'},
+		{'#', '.'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+
+	candidates := crossword.Candidates()
+
+	expectedLetters := [][][]rune{
+		{{'A'}, {'B'}},
+		{nil, {'D'}},
+	}
+	assert.Equal(t, expectedLetters, candidates.Letters)
+}
+
+func TestCandidates_ContainSolutions(t *testing.T) {
+	crossword := newTestingCrossword(t)
+
+	candidates := crossword.Candidates()
+
+	for solution := range crossword.Solve() {
+		for row, rowLetters := range solution {
+			for column, letter := range rowLetters {
+				assert.Contains(t, candidates.Letters[row][column], letter)
+			}
+		}
+	}
+	for _, wordCount := range candidates.WordCounts {
+		assert.LessOrEqual(t, wordCount, 6)
+	}
+}
+
+func TestCandidates_NoFill(t *testing.T) {
+	words := []string{"AB", "CD"}
+	cells := [][]rune{
+		{'.', 'C'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+
+	candidates := crossword.Candidates()
+
+	assert.True(t, slices.ContainsFunc(candidates.Letters[0], func(letters []rune) bool { return len(letters) == 0 }))
+	assert.Equal(t, map[grid.Slot]int{grid.NewAcrossSlot(0, 2, 0): 0}, candidates.WordCounts)
+}
@@ -0,0 +1,97 @@
+package crogo
+
+import (
+	"context"
+	"crogo/pkg/solver"
+	"math"
+	"sync"
+)
+
+// Count is the result of the counting of the fills of a crossword.
+type Count struct {
+	// Value is the number of distinct fills found.
+	Value int
+	// Exhausted indicates whether all the fills have been counted. If false, the crossword has more than Value fills
+	// when counting has been capped by the limit, or at least Value fills when it has been interrupted.
+	Exhausted bool
+}
+
+// Count counts the fills of this crossword using builtin solver, up to the given limit. A limit lower than 1 means no
+// limit.
+func (c *Crossword) Count(limit int) Count {
+	return c.CountWithFactory(context.Background(), solver.NewLogicNgSolver, limit)
+}
+
+// CountWith counts the fills of this crossword using the given solver, up to the given limit. A limit lower than 1 means
+// no limit. Counting stops when the given context is done.
+//
+// Fills are not decoded, only the models of the solver are counted. The given solver must be a new one, see SolveWith.
+// Unlike CountWithFactory, the whole grid is counted at once by the given solver, even if it has independent regions.
+func (c *Crossword) CountWith(ctx context.Context, configurableSolver solver.ConfigurableSolver, limit int) Count {
+	c.addClausesTo(configurableSolver)
+	count := Count{}
+	for range models(ctx, configurableSolver) {
+		if limit > 0 && count.Value == limit {
+			// There is at least one more fill
+			return count
+		}
+		count.Value++
+	}
+	count.Exhausted = ctx.Err() == nil
+	return count
+}
+
+// CountWithFactory counts the fills of this crossword using solvers created by the given factory, up to the given limit.
+// A limit lower than 1 means no limit. Counting stops when the given context is done.
+//
+// Like in SolveWithFactory, regions of the grid separated by blocks are independent: Each one is counted on its own,
+// with its own solver, in parallel, and the number of fills of the crossword is the product of the numbers of fills of
+// its regions.
+func (c *Crossword) CountWithFactory(ctx context.Context, factory solver.Factory, limit int) Count {
+	regions := c.regions()
+	if len(regions) <= 1 || c.breakSymmetries {
+		return c.CountWith(ctx, factory(), limit)
+	}
+	// Regions are counted in parallel, counting is cancelled as soon as a region has no fill
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	regionCounts := make([]Count, len(regions))
+	var waitGroup sync.WaitGroup
+	for i, r := range regions {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			regionCounts[i] = r.crossword.CountWith(ctx, factory(), limit)
+			if regionCounts[i].Exhausted && regionCounts[i].Value == 0 {
+				cancel()
+			}
+		}()
+	}
+	waitGroup.Wait()
+	// Product is bounded just above the limit, so that exceeding it is detected without overflow
+	bound := math.MaxInt
+	if limit > 0 && limit < math.MaxInt {
+		bound = limit + 1
+	}
+	count := Count{Value: 1, Exhausted: true}
+	for _, regionCount := range regionCounts {
+		if regionCount.Exhausted && regionCount.Value == 0 {
+			// A region has no fill, so has the crossword
+			return regionCount
+		}
+		count.Exhausted = count.Exhausted && regionCount.Exhausted
+		if regionCount.Value != 0 && count.Value > bound/regionCount.Value {
+			count.Value = bound
+		} else {
+			count.Value = min(count.Value*regionCount.Value, bound)
+		}
+	}
+	if count.Value == bound {
+		// Product exceeds the limit, or cannot be represented if there is no limit
+		count.Exhausted = false
+		if limit > 0 {
+			count.Value = limit
+		}
+	}
+	return count
+}
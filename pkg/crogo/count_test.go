@@ -0,0 +1,93 @@
+package crogo
+
+import (
+	"context"
+	"crogo/pkg/solver"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	crossword := newTestingCrossword(t)
+
+	assert.Equal(t, Count{Value: 4, Exhausted: true}, crossword.Count(0))
+}
+
+func TestCount_Limit(t *testing.T) {
+	crossword := newTestingCrossword(t)
+
+	assert.Equal(t, Count{Value: 2, Exhausted: false}, crossword.Count(2))
+	assert.Equal(t, Count{Value: 4, Exhausted: true}, crossword.Count(4), "Exact limit shall be exhausted")
+	assert.Equal(t, Count{Value: 4, Exhausted: true}, crossword.Count(10))
+}
+
+func TestCountWith(t *testing.T) {
+	for name, newSolver := range map[string]solver.Factory{
+		"logicng": solver.NewLogicNgSolver,
+		"gini":    solver.NewGiniSolver,
+	} {
+		t.Run(name, func(t *testing.T) {
+			crossword := newTestingCrossword(t)
+
+			count := crossword.CountWith(context.Background(), newSolver(), 0)
+
+			assert.Equal(t, Count{Value: 4, Exhausted: true}, count)
+		})
+	}
+}
+
+func TestCountWith_Unsatisfiable(t *testing.T) {
+	crossword, _ := NewCrossword([][]rune{{'.', '.'}}, []string{"AAA"})
+
+	count := crossword.CountWith(context.Background(), solver.NewLogicNgSolver(), 0)
+
+	assert.Equal(t, Count{Value: 0, Exhausted: true}, count)
+}
+
+func TestCountWith_Cancelled(t *testing.T) {
+	crossword := newTestingCrossword(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count := crossword.CountWith(ctx, solver.NewLogicNgSolver(), 0)
+
+	assert.Equal(t, Count{Value: 0, Exhausted: false}, count)
+}
+
+func TestCountWithFactory_Regions(t *testing.T) {
+	crossword := newTestingRegionsCrossword(t)
+
+	count := crossword.CountWithFactory(context.Background(), solver.NewGiniSolver, 0)
+
+	assert.Equal(t, Count{Value: 4, Exhausted: true}, count)
+}
+
+func TestCountWithFactory_RegionsLimit(t *testing.T) {
+	crossword := newTestingRegionsCrossword(t)
+
+	// Limit 1 is exceeded by each region, limit 3 only by the product of the regions
+	assert.Equal(t, Count{Value: 1, Exhausted: false}, crossword.CountWithFactory(context.Background(),
+		solver.NewGiniSolver, 1))
+	assert.Equal(t, Count{Value: 3, Exhausted: false}, crossword.CountWithFactory(context.Background(),
+		solver.NewGiniSolver, 3))
+	assert.Equal(t, Count{Value: 4, Exhausted: true}, crossword.CountWithFactory(context.Background(),
+		solver.NewGiniSolver, 4), "Exact limit shall be exhausted")
+}
+
+func TestCountWithFactory_RegionWithoutFill(t *testing.T) {
+	crossword, _ := NewCrossword([][]rune{{'.', '.', '#', 'B', '.'}}, []string{"AB", "CD"})
+
+	count := crossword.CountWithFactory(context.Background(), solver.NewLogicNgSolver, 0)
+
+	assert.Equal(t, Count{Value: 0, Exhausted: true}, count)
+}
+
+func TestCountWithFactory_RegionsCancelled(t *testing.T) {
+	crossword := newTestingRegionsCrossword(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count := crossword.CountWithFactory(ctx, solver.NewLogicNgSolver, 0)
+
+	assert.Equal(t, Count{Value: 0, Exhausted: false}, count)
+}
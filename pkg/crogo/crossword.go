@@ -1,59 +1,156 @@
 package crogo
 
 import (
+	"context"
 	. "crogo/internal/constraints"
-	. "crogo/internal/grid"
 	. "crogo/internal/variables"
+	"crogo/pkg/grid"
 	"crogo/pkg/solver"
 	"iter"
 )
 
 // Crossword is the crossword structure, holding variables and constraints information.
 type Crossword struct {
+	grid        *grid.Grid
 	variables   *Variables
 	constraints *Constraints
+	words       []string
+	// seed, if not nil, is the seed randomising the search.
+	seed *uint64
+	// breakSymmetries indicates whether the fills which are images of each other by a symmetry of the grid are
+	// enumerated only once.
+	breakSymmetries bool
 }
 
 // Solutions is an iterator over crossword solutions.
 type Solutions = iter.Seq[[][]rune]
 
 // NewCrossword constructs a new instance of Crossword.
+//
+// Function returns an error if the given cells cannot be solved with the given words. The returned error joins all the
+// errors of the validation report, see Validate. They can be inspected using errors.As. Warnings are ignored.
 func NewCrossword(cells [][]rune, words []string) (*Crossword, error) {
-	grid, err := NewGrid(cells)
+	if err := Validate(cells, words).Err(); err != nil {
+		return nil, err
+	}
+	g, err := grid.NewGrid(cells)
 	if err != nil {
 		return nil, err
 	}
-	variables := NewVariables(grid, len(words))
-	constraints := NewConstraints(grid, variables, words)
-	return &Crossword{variables, constraints}, nil
+	variables := NewVariables(g, len(words))
+	constraints := NewConstraints(g, variables, words)
+	return &Crossword{g, variables, constraints, words, nil, false}, nil
+}
+
+// WithSeed returns a copy of this crossword whose search is randomised using the given seed: The variables and the
+// clauses of the words of each slot are numbered and added to the solver in an order shuffled differently for each
+// slot, which changes the order in which the solver finds the solutions. The decisions of the solver itself, e.g. the
+// phases of its variables, are not randomised: None of the available backends allows to seed them. The same seed, words
+// and grid give the same solutions with the same solver.
+func (c *Crossword) WithSeed(seed uint64) *Crossword {
+	seeded := *c
+	seeded.constraints = c.constraints.WithSeed(seed)
+	seeded.seed = &seed
+	return &seeded
+}
+
+// WithSymmetryBreaking returns a copy of this crossword whose fills which are images of each other by a symmetry of the
+// grid are found only once: Only the smallest one, comparing cells in reading order, is kept.
+//
+// Symmetries are the reflections and rotations mapping the grid to itself - blocks and prefilled letters included - and
+// each slot to a slot read in the same order, i.e. mostly the transposition of square grids, see grid.Grid.Symmetries
+// and grid.Grid.PreservesWords.
+func (c *Crossword) WithSymmetryBreaking() *Crossword {
+	symmetric := *c
+	symmetric.breakSymmetries = true
+	return &symmetric
 }
 
-// Solve solves this crossword using builtin solver.
+// Grid returns the input grid of this crossword.
+func (c *Crossword) Grid() *grid.Grid {
+	return c.grid
+}
+
+// Solve solves this crossword using builtin solver. The returned Solutions can be iterated several times, see
+// SolveWithFactory.
 func (c *Crossword) Solve() Solutions {
-	defaultSolver := solver.NewLogicNgSolver()
-	return c.SolveWith(defaultSolver)
+	return c.SolveWithFactory(context.Background(), solver.NewLogicNgSolver)
 }
 
 // SolveWith solves this crossword using the given solver.
+//
+// The given solver must be a new one: The crossword is encoded into it, and the clauses blocking the solutions found
+// are added to it. Use SolveWithFactory to solve this crossword several times.
 func (c *Crossword) SolveWith(configurableSolver solver.ConfigurableSolver) Solutions {
+	return c.SolveWithContext(context.Background(), configurableSolver)
+}
+
+// SolveWithContext solves this crossword using the given solver, see SolveWith. Iteration stops when the given context
+// is done; If the given solver is a solver.InterruptibleSolver, the search for the next solution is interrupted as
+// well.
+func (c *Crossword) SolveWithContext(ctx context.Context, configurableSolver solver.ConfigurableSolver) Solutions {
 	c.addClausesTo(configurableSolver)
-	return c.solutions(configurableSolver)
+	return c.solutions(ctx, configurableSolver)
 }
 
-// addClausesTo adds clauses to the given solver configurer.
-func (c *Crossword) addClausesTo(solverConfigurer solver.Configurer) {
+// SolveWithFactory solves this crossword using solvers created by the given factory. Unlike the Solutions returned by
+// SolveWith, the returned Solutions can be iterated several times, possibly concurrently: Each iteration encodes the
+// crossword into new solvers. Iteration stops when the given context is done.
+//
+// Regions of the grid separated by blocks, see grid.Grid.Regions, are independent: They are solved separately, each
+// with its own solver, and their solutions are combined lazily. The first solutions of the regions are searched in
+// parallel. Regions are solved together if symmetries are broken, since symmetries may exchange regions.
+//
+// Crossword is never modified by solving, so it can also be solved concurrently by several SolveWith calls, as long as
+// they are given distinct solvers.
+func (c *Crossword) SolveWithFactory(ctx context.Context, factory solver.Factory) Solutions {
+	if regions := c.regions(); len(regions) > 1 && !c.breakSymmetries {
+		return c.solveRegions(ctx, regions, factory)
+	}
+	return func(yield func([][]rune) bool) {
+		c.SolveWithContext(ctx, factory())(yield)
+	}
+}
+
+// addClausesTo adds clauses to the given solver configurer. Function returns the first variable which is not used by
+// the clauses, from which further auxiliary variables can be numbered.
+func (c *Crossword) addClausesTo(solverConfigurer solver.Configurer) solver.Variable {
 	solverConfigurer.AllocateVariables(uint(c.variables.Count()))
 	solverConfigurer.SetRelevantVariables(c.variables.RepresentingCells())
 	c.constraints.AddOneLetterOrBlockPerCellClausesTo(solverConfigurer)
 	c.constraints.AddOneWordPerSlotClausesTo(solverConfigurer)
 	c.constraints.AddInputGridConstraintsAreSatisfiedClausesTo(solverConfigurer)
+	nextVariable := solver.Variable(c.variables.Count() + 1)
+	if c.breakSymmetries {
+		nextVariable = c.constraints.AddSymmetryBreakingClausesTo(solverConfigurer, nextVariable)
+		solverConfigurer.AllocateVariables(uint(nextVariable - 1))
+	}
+	return nextVariable
 }
 
-func (c *Crossword) solutions(s solver.Solver) Solutions {
+func (c *Crossword) solutions(ctx context.Context, s solver.Solver) Solutions {
 	return func(yield func([][]rune) bool) {
+		for model := range models(ctx, s) {
+			if !yield(c.variables.BackToDomain(model)) {
+				return
+			}
+		}
+	}
+}
+
+// models returns an iterator on the models of the given solver, which stops when the given context is done.
+func models(ctx context.Context, s solver.Solver) iter.Seq[solver.Model] {
+	return func(yield func(solver.Model) bool) {
 		adaptedYield := func(model solver.Model) bool {
-			return yield(c.variables.BackToDomain(model))
+			if ctx.Err() != nil {
+				return false
+			}
+			return yield(model)
+		}
+		if interruptibleSolver, ok := s.(solver.InterruptibleSolver); ok {
+			interruptibleSolver.SolutionsWithContext(ctx)(adaptedYield)
+		} else {
+			s.Solutions()(adaptedYield)
 		}
-		s.Solutions()(adaptedYield)
 	}
 }
@@ -1,12 +1,16 @@
 package crogo
 
 import (
+	"context"
 	"crogo/pkg/dictionaries"
+	"crogo/pkg/grid"
+	"crogo/pkg/solver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"iter"
 	"reflect"
 	"slices"
+	"sync"
 	"testing"
 )
 
@@ -24,7 +28,19 @@ func TestNewCrossword_Error(t *testing.T) {
 	words := []string{"ABC", "DEF", "AA", "BB", "CC"}
 	cells := [][]rune{{'_', '_', '_'}}
 	_, err := NewCrossword(cells, words)
-	assert.EqualError(t, err, "invalid value at row #0, column #0: _")
+	assert.EqualError(t, err, "invalid value at row #0, column #0: _\n"+
+		"invalid value at row #0, column #1: _\n"+
+		"invalid value at row #0, column #2: _")
+	var unsupportedCharacterError *grid.UnsupportedCharacterError
+	require.ErrorAs(t, err, &unsupportedCharacterError)
+	assert.Equal(t, grid.UnsupportedCharacterError{Row: 0, Column: 0, Value: '_'}, *unsupportedCharacterError)
+}
+
+func TestNewCrossword_SlotTooLong(t *testing.T) {
+	words := []string{"AB", "CD"}
+	cells := [][]rune{{'.', '.', '.'}}
+	_, err := NewCrossword(cells, words)
+	assert.EqualError(t, err, "slot too long at row #0, column #0: slot has 3 cells but longest word has 2 letters")
 }
 
 func TestSolve_Unsat(t *testing.T) {
@@ -44,12 +60,12 @@ func TestSolve_Unsat(t *testing.T) {
 
 func TestSolve_Sat_Simple(t *testing.T) {
 	words := []string{"AAA", "BBB", "CDE", "ABC", "ABD", "ABE"}
-	grid := [][]rune{
+	cells := [][]rune{
 		{'.', '.', '.'},
 		{'.', '.', '.'},
 		{'.', '.', '.'},
 	}
-	crossword, _ := NewCrossword(grid, words)
+	crossword, _ := NewCrossword(cells, words)
 
 	actualSolutions := crossword.Solve()
 
@@ -80,12 +96,12 @@ func TestSolve_Sat_Simple(t *testing.T) {
 
 func TestSolve_Sat_Simple_Prefilled(t *testing.T) {
 	words := []string{"AAA", "BBB", "CDE", "ABC", "ABD", "ABE"}
-	grid := [][]rune{
+	cells := [][]rune{
 		{'A', '.', '.'},
 		{'B', '.', '.'},
 		{'.', '.', '.'},
 	}
-	crossword, _ := NewCrossword(grid, words)
+	crossword, _ := NewCrossword(cells, words)
 
 	actualSolutions := crossword.Solve()
 
@@ -101,12 +117,12 @@ func TestSolve_Sat_Simple_Prefilled(t *testing.T) {
 
 func TestSolve_Sat_Complex(t *testing.T) {
 	words := dictionaries.Ukacd()
-	grid := [][]rune{
+	cells := [][]rune{
 		{'.', '.', '.'},
 		{'.', '.', '.'},
 		{'.', '.', '.'},
 	}
-	crossword, _ := NewCrossword(grid, words)
+	crossword, _ := NewCrossword(cells, words)
 
 	solutionsIter := crossword.Solve()
 
@@ -130,6 +146,133 @@ func TestSolve_Sat_Complex(t *testing.T) {
 	assertNextSolutionsEqual(t, expectedNextSolutions, solutionsIter)
 }
 
+func TestSolveWithContext_Cancelled(t *testing.T) {
+	words := []string{"AAA", "BBB", "CDE", "ABC", "ABD", "ABE"}
+	cells := [][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	}
+	crossword, _ := NewCrossword(cells, words)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	solutions := crossword.SolveWithContext(ctx, solver.NewGiniSolver())
+	getNextSolution, stop := iter.Pull(solutions)
+	defer stop()
+	_, found := getNextSolution()
+	require.True(t, found)
+	cancel()
+	_, found = getNextSolution()
+
+	assert.False(t, found)
+}
+
+func TestSolve_Repeatable(t *testing.T) {
+	words := []string{"AAA", "BBB", "CDE", "ABC", "ABD", "ABE"}
+	cells := [][]rune{
+		{'A', '.', '.'},
+		{'B', '.', '.'},
+		{'.', '.', '.'},
+	}
+	crossword, _ := NewCrossword(cells, words)
+
+	solutions := crossword.Solve()
+
+	expectedSolutions := [][][]rune{
+		{
+			{'A', 'A', 'A'},
+			{'B', 'B', 'B'},
+			{'C', 'D', 'E'},
+		},
+	}
+	assertSolutionsEqual(t, slices.Clone(expectedSolutions), solutions)
+	assertSolutionsEqual(t, expectedSolutions, solutions)
+}
+
+func TestSolveWith_Concurrent(t *testing.T) {
+	crossword := newTestingCrossword(t)
+
+	const solveCount = 4
+	results := make([][][][]rune, solveCount)
+	var wg sync.WaitGroup
+	for i := range solveCount {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for solution := range crossword.SolveWith(solver.NewGiniSolver()) {
+				results[i] = append(results[i], solution)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		assert.Len(t, result, 4)
+		assert.ElementsMatch(t, results[0], result)
+	}
+}
+
+func TestSolveWithFactory_Concurrent(t *testing.T) {
+	crossword := newTestingCrossword(t)
+	solutions := crossword.SolveWithFactory(context.Background(), solver.NewGiniSolver)
+
+	// Each iteration stops at a different count, the others must not be affected
+	const iterationCount = 4
+	results := make([][][][]rune, iterationCount)
+	var wg sync.WaitGroup
+	for i := range iterationCount {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for solution := range solutions {
+				results[i] = append(results[i], solution)
+				if len(results[i]) == i+1 {
+					break
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		assert.Len(t, result, i+1)
+	}
+	assertSolutionsEqual(t, results[iterationCount-1], solutions)
+}
+
+func TestWithSeed(t *testing.T) {
+	crossword := newTestingCrossword(t)
+	for name, newSolver := range map[string]solver.Factory{
+		"logicng": solver.NewLogicNgSolver,
+		"gini":    solver.NewGiniSolver,
+	} {
+		t.Run(name, func(t *testing.T) {
+			firstSolutions := make(map[string]bool)
+			for seed := range uint64(10) {
+				first := firstSolution(t, crossword.WithSeed(seed), newSolver)
+				assert.Equal(t, first, firstSolution(t, crossword.WithSeed(seed), newSolver),
+					"Same seed shall give same solution")
+				firstSolutions[string(slices.Concat(first...))] = true
+			}
+			assert.Greater(t, len(firstSolutions), 1, "Different seeds shall give different solutions")
+		})
+	}
+}
+
+// newTestingCrossword returns an empty 3x3 crossword with four fills: AAA/AAA/AAA, BBB/BBB/BBB, AAA/BBB/CDE and its
+// transposition ABC/ABD/ABE.
+func newTestingCrossword(t *testing.T) *Crossword {
+	words := []string{"AAA", "BBB", "CDE", "ABC", "ABD", "ABE"}
+	cells := [][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+	return crossword
+}
+
 func assertSolutionsEqual(t *testing.T, expected [][][]rune, actual iter.Seq[[][]rune]) {
 	expectedRemaining := expected
 	for actualSolution := range actual {
@@ -151,3 +294,11 @@ func assertNextSolutionsEqual(t *testing.T, someExpected [][][]rune, actualIter
 		require.True(t, reflect.DeepEqual(expected, actual), "Expected %c, got %c", expected, actual)
 	}
 }
+
+func firstSolution(t *testing.T, crossword *Crossword, newSolver solver.Factory) [][]rune {
+	for solution := range crossword.SolveWith(newSolver()) {
+		return solution
+	}
+	require.Fail(t, "No solution found")
+	return nil
+}
@@ -0,0 +1,162 @@
+package crogo
+
+import (
+	"context"
+	"crogo/internal/alphabet"
+	"crogo/pkg/grid"
+	"crogo/pkg/solver"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Diversity defines how different the solutions of an enumeration must be.
+//
+// By default, solutions differ by at least one letter. A Diversity projects the enumeration on the words of some slots:
+// Each solution differs from all the previous ones in at least MinDifferentWords of these slots.
+type Diversity struct {
+	// Slots are the slots whose words distinguish the solutions. Nil means all the slots of the grid.
+	Slots []grid.Slot
+	// MinDifferentWords is the minimal number of Slots whose words differ between any two solutions. Values lower than
+	// 1 mean 1.
+	MinDifferentWords int
+}
+
+// SolveDiverse solves this crossword using the given solver, returning solutions as different as defined by the given
+// diversity. Iteration stops when the given context is done.
+//
+// The given solver must be a new solver.IncrementalSolver: The crossword is encoded into it, and the constraints
+// ensuring the diversity of the solutions are added to it after each solution. Function returns an error if the solver
+// is not a solver.IncrementalSolver or if the diversity slots are not slots of the grid.
+func (c *Crossword) SolveDiverse(ctx context.Context, configurableSolver solver.ConfigurableSolver,
+	diversity Diversity) (Solutions, error) {
+	incrementalSolver, ok := configurableSolver.(solver.IncrementalSolver)
+	if !ok {
+		return nil, errors.New("solver does not support incremental solving")
+	}
+	slots, err := c.diversitySlots(diversity.Slots)
+	if err != nil {
+		return nil, err
+	}
+	minDifferentWords := max(diversity.MinDifferentWords, 1)
+	firstVariable := c.addClausesTo(configurableSolver)
+	return func(yield func([][]rune) bool) {
+		// Auxiliary variables are placed after the crossword variables
+		nextVariable := firstVariable
+		newVariable := func() solver.Variable {
+			nextVariable++
+			return nextVariable - 1
+		}
+		for ctx.Err() == nil {
+			model, err := incrementalSolver.SolveUnder(ctx, nil)
+			if err != nil || model == nil {
+				return
+			}
+			solution := c.variables.BackToDomain(model)
+			if !yield(solution) {
+				return
+			}
+			c.addDifferentWordsClausesTo(configurableSolver, solution, slots, minDifferentWords, newVariable)
+		}
+	}, nil
+}
+
+// diversitySlots returns the given slots, checking they are slots of the grid, or all the slots of the grid if nil.
+func (c *Crossword) diversitySlots(slots []grid.Slot) ([]grid.Slot, error) {
+	gridSlots := c.grid.Slots()
+	if slots == nil {
+		return gridSlots, nil
+	}
+	for _, slot := range slots {
+		if !slices.Contains(gridSlots, slot) {
+			return nil, fmt.Errorf("slot starting at %v is not a slot of the grid", slot.Start())
+		}
+	}
+	return slots, nil
+}
+
+// addDifferentWordsClausesTo adds the clauses ensuring that the next solutions differ from the given solution in at
+// least minDifferentWords of the given slots, using the given function to create auxiliary variables.
+func (c *Crossword) addDifferentWordsClausesTo(solverConfigurer solver.Configurer, solution [][]rune,
+	slots []grid.Slot, minDifferentWords int, newVariable func() solver.Variable) {
+	if minDifferentWords == 1 {
+		// A single clause suffices: At least one letter of the slots differs
+		solverConfigurer.AddClause(c.differentLetterLiterals(solution, slices.Concat(positionsOf(slots)...)))
+		return
+	}
+	// differentWords[i] implies that the word of slot i differs
+	differentWords := make([]solver.Literal, len(slots))
+	for i, positions := range positionsOf(slots) {
+		differentWords[i] = solver.Literal(newVariable())
+		clause := append(c.differentLetterLiterals(solution, positions), differentWords[i].Negated())
+		solverConfigurer.AddClause(clause)
+	}
+	addAtLeastClausesTo(solverConfigurer, differentWords, minDifferentWords, newVariable)
+}
+
+// differentLetterLiterals returns the literals stating that the letter of the given positions differs from the given
+// solution.
+func (c *Crossword) differentLetterLiterals(solution [][]rune, positions []grid.Pos) []solver.Literal {
+	literals := make([]solver.Literal, 0, len(positions))
+	for _, pos := range slices.Compact(slices.SortedFunc(slices.Values(positions), comparePos)) {
+		letterIndex, _ := alphabet.IndexOf(solution[pos.Row()][pos.Column()])
+		literal := solver.Literal(c.variables.RepresentingCell(pos.Row(), pos.Column(), letterIndex))
+		literals = append(literals, literal.Negated())
+	}
+	return literals
+}
+
+// addAtLeastClausesTo adds the clauses ensuring that at least k of the given literals are true, using the given
+// function to create auxiliary variables.
+//
+// It is the half of the sequential counter encoding needed for an at-least constraint: Auxiliary variable r(i,j)
+// implies that at least j of the i first literals are true.
+func addAtLeastClausesTo(solverConfigurer solver.Configurer, literals []solver.Literal, k int,
+	newVariable func() solver.Variable) {
+	// previous[j-1] is r(i-1,j); r(0,j) is false for all j >= 1
+	var previous []solver.Literal
+	for i, literal := range literals {
+		current := make([]solver.Literal, min(i+1, k))
+		for j := range current {
+			current[j] = solver.Literal(newVariable())
+			// r(i,j) ⇒ r(i-1,j) ∨ literal
+			clause := []solver.Literal{current[j].Negated(), literal}
+			if j < len(previous) {
+				clause = append(clause, previous[j])
+			}
+			solverConfigurer.AddClause(clause)
+			// r(i,j) ⇒ r(i-1,j) ∨ r(i-1,j-1)
+			if j > 0 {
+				clause = []solver.Literal{current[j].Negated(), previous[j-1]}
+				if j < len(previous) {
+					clause = append(clause, previous[j])
+				}
+				solverConfigurer.AddClause(clause)
+			}
+		}
+		previous = current
+	}
+	if len(previous) < k {
+		// Not enough literals
+		solverConfigurer.AddClause(nil)
+		return
+	}
+	solverConfigurer.AddClause([]solver.Literal{previous[k-1]})
+}
+
+// positionsOf returns the positions of each of the given slots.
+func positionsOf(slots []grid.Slot) [][]grid.Pos {
+	positions := make([][]grid.Pos, len(slots))
+	for i, slot := range slots {
+		positions[i] = slot.Positions()
+	}
+	return positions
+}
+
+// comparePos orders positions in reading order.
+func comparePos(a, b grid.Pos) int {
+	if a.Row() != b.Row() {
+		return a.Row() - b.Row()
+	}
+	return a.Column() - b.Column()
+}
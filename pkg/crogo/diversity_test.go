@@ -0,0 +1,89 @@
+package crogo
+
+import (
+	"context"
+	internalsolver "crogo/internal/solver"
+	"crogo/pkg/grid"
+	"crogo/pkg/solver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSolveDiverse_AllSlots(t *testing.T) {
+	crossword := newTestingCrossword(t)
+
+	solutions, err := crossword.SolveDiverse(context.Background(), solver.NewGiniSolver(), Diversity{})
+	require.NoError(t, err)
+
+	var solutionCount int
+	for range solutions {
+		solutionCount++
+	}
+	assert.Equal(t, 4, solutionCount)
+}
+
+func TestSolveDiverse_Projected(t *testing.T) {
+	for name, newSolver := range map[string]solver.Factory{
+		"logicng":   solver.NewLogicNgSolver,
+		"gini":      solver.NewGiniSolver,
+		"gophersat": internalsolver.NewGophersatSolver,
+	} {
+		t.Run(name, func(t *testing.T) {
+			crossword := newTestingCrossword(t)
+			firstAcross := crossword.Grid().Slots()[0]
+
+			solutions, err := crossword.SolveDiverse(context.Background(), newSolver(),
+				Diversity{Slots: []grid.Slot{firstAcross}})
+			require.NoError(t, err)
+
+			var firstAcrossWords []string
+			for solution := range solutions {
+				firstAcrossWords = append(firstAcrossWords, string(solution[0]))
+			}
+			assert.ElementsMatch(t, []string{"AAA", "ABC", "BBB"}, firstAcrossWords)
+		})
+	}
+}
+
+func TestSolveDiverse_MinDifferentWords(t *testing.T) {
+	for _, minDifferentWords := range []int{2, 5, 6, 7} {
+		crossword := newTestingCrossword(t)
+
+		solutions, err := crossword.SolveDiverse(context.Background(), solver.NewLogicNgSolver(),
+			Diversity{MinDifferentWords: minDifferentWords})
+		require.NoError(t, err)
+
+		var previousSolutions [][][]rune
+		for solution := range solutions {
+			for _, previousSolution := range previousSolutions {
+				assert.GreaterOrEqual(t, differentWordCount(crossword.Grid(), previousSolution, solution),
+					minDifferentWords)
+			}
+			previousSolutions = append(previousSolutions, solution)
+		}
+		assert.NotEmpty(t, previousSolutions)
+		if minDifferentWords > 6 {
+			assert.Len(t, previousSolutions, 1, "Grid has only 6 slots")
+		}
+	}
+}
+
+func TestSolveDiverse_Error(t *testing.T) {
+	crossword := newTestingCrossword(t)
+
+	_, err := crossword.SolveDiverse(context.Background(), solver.NewGiniSolver(),
+		Diversity{Slots: []grid.Slot{grid.NewAcrossSlot(0, 2, 0)}})
+	assert.EqualError(t, err, "slot starting at (row #0, column #0) is not a slot of the grid")
+}
+
+func differentWordCount(g *grid.Grid, a, b [][]rune) int {
+	count := 0
+	entriesB := g.Entries(b)
+	for i, entryA := range g.Entries(a) {
+		if entryA.Word() != entriesB[i].Word() {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,128 @@
+package crogo
+
+import (
+	"context"
+	. "crogo/internal/constraints"
+	. "crogo/internal/variables"
+	"crogo/pkg/grid"
+	"crogo/pkg/solver"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// region is a crossword restricted to a region of the input grid, see grid.Grid.Regions.
+type region struct {
+	// crossword is the crossword whose cells outside the region are blocks. Since regions are separated by blocks, it
+	// has the same slots as the region.
+	crossword *Crossword
+	// positions are the positions of the cells of the region.
+	positions []grid.Pos
+}
+
+// regions returns the regions of this crossword grid.
+func (c *Crossword) regions() []region {
+	gridRegions := c.grid.Regions()
+	regions := make([]region, len(gridRegions))
+	for i, positions := range gridRegions {
+		cells := make([][]rune, c.grid.RowCount())
+		for row := range cells {
+			cells[row] = make([]rune, c.grid.ColumnCount())
+			for column := range cells[row] {
+				cells[row][column] = grid.CellBlock
+			}
+		}
+		for _, pos := range positions {
+			cells[pos.Row()][pos.Column()] = c.grid.LetterAt(pos.Row(), pos.Column())
+		}
+		// Cells are valid since they are a subset of the input grid
+		g, _ := grid.NewGrid(cells)
+		variables := NewVariables(g, len(c.words))
+		constraints := NewConstraints(g, variables, c.words)
+		if c.seed != nil {
+			constraints = constraints.WithSeed(*c.seed)
+		}
+		regions[i] = region{&Crossword{g, variables, constraints, c.words, c.seed, false}, positions}
+	}
+	return regions
+}
+
+// solveRegions solves the given regions of this crossword separately, using solvers created by the given factory, and
+// combines their solutions lazily. Iteration stops when the given context is done.
+//
+// Since the words of a crossword may be repeated, the solutions of the regions can be combined without any check.
+func (c *Crossword) solveRegions(ctx context.Context, regions []region, factory solver.Factory) Solutions {
+	return func(yield func([][]rune) bool) {
+		// Searches of the other regions are cancelled as soon as a region has no solution
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		regionSolutions := make([]*cachedSolutions, len(regions))
+		for i, r := range regions {
+			next, stop := iter.Pull(r.crossword.SolveWithFactory(ctx, factory))
+			defer stop()
+			regionSolutions[i] = &cachedSolutions{next: next}
+		}
+		// The first solutions of the regions are searched in parallel
+		var waitGroup sync.WaitGroup
+		for _, solutions := range regionSolutions {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				if _, found := solutions.at(0); !found {
+					// A region has no solution, so has the crossword
+					cancel()
+				}
+			}()
+		}
+		waitGroup.Wait()
+		if ctx.Err() != nil {
+			return
+		}
+		yieldCombinations(ctx, regions, regionSolutions, c.grid.Cells(), yield)
+	}
+}
+
+// yieldCombinations yields the combinations of the solutions of the given regions, starting at the first one, written
+// into the given cells. It returns false if the iteration has been stopped.
+func yieldCombinations(ctx context.Context, regions []region, regionSolutions []*cachedSolutions, cells [][]rune,
+	yield func([][]rune) bool) bool {
+	if len(regions) == 0 {
+		solution := make([][]rune, len(cells))
+		for row, rowCells := range cells {
+			solution[row] = slices.Clone(rowCells)
+		}
+		return yield(solution)
+	}
+	for index := 0; ctx.Err() == nil; index++ {
+		solution, found := regionSolutions[0].at(index)
+		if !found {
+			return true
+		}
+		for _, pos := range regions[0].positions {
+			cells[pos.Row()][pos.Column()] = solution[pos.Row()][pos.Column()]
+		}
+		if !yieldCombinations(ctx, regions[1:], regionSolutions[1:], cells, yield) {
+			return false
+		}
+	}
+	return false
+}
+
+// cachedSolutions are solutions pulled lazily from an iterator and cached, so that they can be iterated several times.
+type cachedSolutions struct {
+	next      func() ([][]rune, bool)
+	solutions [][][]rune
+}
+
+// at returns the solution of the given index, pulling solutions from the iterator if needed. It returns false if the
+// iterator has fewer solutions.
+func (s *cachedSolutions) at(index int) ([][]rune, bool) {
+	for len(s.solutions) <= index {
+		solution, found := s.next()
+		if !found {
+			return nil, false
+		}
+		s.solutions = append(s.solutions, solution)
+	}
+	return s.solutions[index], true
+}
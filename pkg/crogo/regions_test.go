@@ -0,0 +1,83 @@
+package crogo
+
+import (
+	"context"
+	"crogo/pkg/solver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+)
+
+func newTestingRegionsCrossword(t *testing.T) *Crossword {
+	words := []string{"AB", "CD", "ABC"}
+	cells := [][]rune{
+		{'.', '.', '#', '.', '.'},
+		{'#', '#', '#', '#', '#'},
+		{'.', '.', '.', '#', 'A'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+	return crossword
+}
+
+func TestSolveWithFactory_Regions(t *testing.T) {
+	crossword := newTestingRegionsCrossword(t)
+
+	solutions := crossword.SolveWithFactory(context.Background(), solver.NewLogicNgSolver)
+
+	// Region made of the single cell containing 'A' has no slot, it is kept as is
+	expectedSolutions := [][][]rune{
+		{[]rune("AB#AB"), []rune("#####"), []rune("ABC#A")},
+		{[]rune("AB#CD"), []rune("#####"), []rune("ABC#A")},
+		{[]rune("CD#AB"), []rune("#####"), []rune("ABC#A")},
+		{[]rune("CD#CD"), []rune("#####"), []rune("ABC#A")},
+	}
+	assertSolutionsEqual(t, expectedSolutions, solutions)
+}
+
+func TestSolveWithFactory_RegionsSameAsJoint(t *testing.T) {
+	crossword := newTestingRegionsCrossword(t)
+
+	jointSolutions := slices.Collect(crossword.SolveWith(solver.NewGiniSolver()))
+	solutions := crossword.SolveWithFactory(context.Background(), solver.NewGiniSolver)
+
+	assertSolutionsEqual(t, jointSolutions, solutions)
+}
+
+func TestSolveWithFactory_RegionWithoutSolution(t *testing.T) {
+	words := []string{"AB", "CD"}
+	cells := [][]rune{
+		{'.', '.', '#', 'B', '.'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+
+	solutions := slices.Collect(crossword.SolveWithFactory(context.Background(), solver.NewLogicNgSolver))
+
+	assert.Empty(t, solutions)
+}
+
+func TestSolveWithFactory_RegionsStop(t *testing.T) {
+	crossword := newTestingRegionsCrossword(t)
+
+	var solutionCount int
+	for range crossword.SolveWithFactory(context.Background(), solver.NewLogicNgSolver) {
+		solutionCount++
+		if solutionCount == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, 3, solutionCount)
+}
+
+func TestSolveWithFactory_RegionsCancelled(t *testing.T) {
+	crossword := newTestingRegionsCrossword(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	solutions := slices.Collect(crossword.SolveWithFactory(ctx, solver.NewLogicNgSolver))
+
+	assert.Empty(t, solutions)
+}
@@ -0,0 +1,87 @@
+package crogo
+
+import (
+	"context"
+	"crogo/internal/alphabet"
+	"crogo/pkg/grid"
+	"crogo/pkg/solver"
+	"errors"
+	"fmt"
+)
+
+// Session is a crossword encoded once into an incremental solver, which can then be solved repeatedly with different
+// letters in its cells, without encoding the problem again.
+//
+// The letters given to Solutions are passed to the solver as assumptions, on top of the letters of the crossword input
+// grid. The block pattern cannot change though, since it defines the slots of the encoded problem.
+type Session struct {
+	crossword *Crossword
+	solver    solver.IncrementalSolver
+}
+
+// NewSession encodes this crossword into the given solver and returns a session on it. The given solver shall not be
+// used for anything else afterward.
+//
+// Function returns an error if the given solver is not a solver.IncrementalSolver.
+func (c *Crossword) NewSession(configurableSolver solver.ConfigurableSolver) (*Session, error) {
+	incrementalSolver, ok := configurableSolver.(solver.IncrementalSolver)
+	if !ok {
+		return nil, errors.New("solver does not support incremental solving")
+	}
+	c.addClausesTo(configurableSolver)
+	return &Session{c, incrementalSolver}, nil
+}
+
+// Grid returns the input grid of the crossword of this session.
+func (s *Session) Grid() *grid.Grid {
+	return s.crossword.grid
+}
+
+// Solutions returns the solutions of the crossword whose cells contain the letters of the given cells. Iteration stops
+// when the given context is done.
+//
+// Function returns an error if the given cells do not have the same dimensions and blocks as the crossword grid, or if
+// they contain letters outside the alphabet.
+func (s *Session) Solutions(ctx context.Context, cells [][]rune) (Solutions, error) {
+	assumptions, err := s.assumptionsFrom(cells)
+	if err != nil {
+		return nil, err
+	}
+	return func(yield func([][]rune) bool) {
+		for model := range s.solver.SolutionsUnder(ctx, assumptions) {
+			if ctx.Err() != nil || !yield(s.crossword.variables.BackToDomain(model)) {
+				return
+			}
+		}
+	}, nil
+}
+
+// assumptionsFrom returns the literals forcing the letters of the given cells.
+func (s *Session) assumptionsFrom(cells [][]rune) ([]solver.Literal, error) {
+	g := s.crossword.grid
+	if len(cells) != g.RowCount() {
+		return nil, fmt.Errorf("expected %d rows, got %d", g.RowCount(), len(cells))
+	}
+	var assumptions []solver.Literal
+	for row, rowCells := range cells {
+		if len(rowCells) != g.ColumnCount() {
+			return nil, fmt.Errorf("expected %d columns at row #%d, got %d", g.ColumnCount(), row, len(rowCells))
+		}
+		for column, cell := range rowCells {
+			isBlock := g.LetterAt(row, column) == grid.CellBlock
+			switch {
+			case isBlock != (cell == grid.CellBlock):
+				return nil, fmt.Errorf("block pattern differs at row #%d, column #%d", row, column)
+			case cell == grid.CellBlock || cell == grid.CellEmpty:
+				continue
+			}
+			index, ok := alphabet.IndexOf(cell)
+			if !ok {
+				return nil, fmt.Errorf("invalid value at row #%d, column #%d: %c", row, column, cell)
+			}
+			variable := s.crossword.variables.RepresentingCell(row, column, index)
+			assumptions = append(assumptions, solver.Literal(variable))
+		}
+	}
+	return assumptions, nil
+}
@@ -0,0 +1,83 @@
+package crogo
+
+import (
+	"context"
+	internalsolver "crogo/internal/solver"
+	"crogo/pkg/solver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestingSession(t *testing.T, configurableSolver solver.ConfigurableSolver) *Session {
+	session, err := newTestingCrossword(t).NewSession(configurableSolver)
+	require.NoError(t, err)
+	return session
+}
+
+func TestSession_Solutions(t *testing.T) {
+	for name, newSolver := range map[string]func() solver.ConfigurableSolver{
+		"logicng":   solver.NewLogicNgSolver,
+		"gini":      solver.NewGiniSolver,
+		"gophersat": internalsolver.NewGophersatSolver,
+	} {
+		t.Run(name, func(t *testing.T) {
+			testSessionSolutions(t, newTestingSession(t, newSolver()))
+		})
+	}
+}
+
+func testSessionSolutions(t *testing.T, session *Session) {
+	solutions, err := session.Solutions(context.Background(), [][]rune{
+		{'A', '.', '.'},
+		{'B', '.', '.'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	assertSolutionsEqual(t, [][][]rune{{
+		{'A', 'A', 'A'},
+		{'B', 'B', 'B'},
+		{'C', 'D', 'E'},
+	}}, solutions)
+
+	// Previous assumptions and enumeration do not affect the next calls
+	solutions, err = session.Solutions(context.Background(), [][]rune{
+		{'.', '.', '.'},
+		{'.', 'B', '.'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	assertSolutionsEqual(t, [][][]rune{
+		{
+			{'B', 'B', 'B'},
+			{'B', 'B', 'B'},
+			{'B', 'B', 'B'},
+		},
+		{
+			{'A', 'B', 'C'},
+			{'A', 'B', 'D'},
+			{'A', 'B', 'E'},
+		},
+		{
+			{'A', 'A', 'A'},
+			{'B', 'B', 'B'},
+			{'C', 'D', 'E'},
+		},
+	}, solutions)
+}
+
+func TestSession_Solutions_Error(t *testing.T) {
+	session := newTestingSession(t, solver.NewGiniSolver())
+
+	_, err := session.Solutions(context.Background(), [][]rune{{'.', '.', '.'}})
+	assert.EqualError(t, err, "expected 3 rows, got 1")
+
+	_, err = session.Solutions(context.Background(), [][]rune{{'.', '.', '.'}, {'.', '.'}, {'.', '.', '.'}})
+	assert.EqualError(t, err, "expected 3 columns at row #1, got 2")
+
+	_, err = session.Solutions(context.Background(), [][]rune{{'.', '.', '.'}, {'.', '#', '.'}, {'.', '.', '.'}})
+	assert.EqualError(t, err, "block pattern differs at row #1, column #1")
+
+	_, err = session.Solutions(context.Background(), [][]rune{{'.', '.', '.'}, {'.', 'é', '.'}, {'.', '.', '.'}})
+	assert.EqualError(t, err, "invalid value at row #1, column #1: é")
+}
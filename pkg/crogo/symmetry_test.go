@@ -0,0 +1,105 @@
+package crogo
+
+import (
+	"context"
+	"crogo/pkg/solver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+)
+
+func TestWithSymmetryBreaking(t *testing.T) {
+	words := []string{"AB", "CD", "AC", "BD"}
+	cells := [][]rune{
+		{'.', '.'},
+		{'.', '.'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+
+	solutions := crossword.WithSymmetryBreaking().Solve()
+
+	// AC,BD is the transposition of AB,CD; Other fills are their own transposition
+	expectedSolutions := [][][]rune{
+		{[]rune("AB"), []rune("CD")},
+		{[]rune("AB"), []rune("BD")},
+		{[]rune("AC"), []rune("CD")},
+	}
+	assertSolutionsEqual(t, expectedSolutions, solutions)
+}
+
+func TestWithSymmetryBreaking_OneRepresentativePerClass(t *testing.T) {
+	for name, newSolver := range map[string]solver.Factory{
+		"logicng": solver.NewLogicNgSolver,
+		"gini":    solver.NewGiniSolver,
+	} {
+		t.Run(name, func(t *testing.T) {
+			crossword := newTestingCrossword(t)
+			allSolutions := slices.Collect(crossword.SolveWithFactory(context.Background(), newSolver))
+
+			representatives := slices.Collect(
+				crossword.WithSymmetryBreaking().SolveWithFactory(context.Background(), newSolver))
+
+			for _, solution := range allSolutions {
+				representative := slices.MinFunc([][][]rune{solution, transposed(solution)}, compareCells)
+				assert.Contains(t, representatives, representative)
+			}
+			for _, representative := range representatives {
+				assert.Contains(t, allSolutions, representative)
+				assert.LessOrEqual(t, compareCells(representative, transposed(representative)), 0)
+			}
+			assert.Less(t, len(representatives), len(allSolutions))
+		})
+	}
+}
+
+func TestWithSymmetryBreaking_NotSymmetric(t *testing.T) {
+	words := []string{"AB", "CD", "AC", "BD"}
+	cells := [][]rune{
+		{'.', 'B'},
+		{'.', '.'},
+	}
+	crossword, err := NewCrossword(cells, words)
+	require.NoError(t, err)
+
+	solutions := crossword.WithSymmetryBreaking().Solve()
+
+	expectedSolutions := [][][]rune{
+		{[]rune("AB"), []rune("CD")},
+		{[]rune("AB"), []rune("BD")},
+	}
+	assertSolutionsEqual(t, expectedSolutions, solutions)
+}
+
+func TestWithSymmetryBreaking_Diverse(t *testing.T) {
+	crossword := newTestingCrossword(t).WithSymmetryBreaking()
+
+	solutions, err := crossword.SolveDiverse(context.Background(), solver.NewGiniSolver(),
+		Diversity{MinDifferentWords: 2})
+	require.NoError(t, err)
+
+	var solutionCount int
+	for solution := range solutions {
+		assert.LessOrEqual(t, compareCells(solution, transposed(solution)), 0)
+		solutionCount++
+	}
+	assert.Positive(t, solutionCount)
+}
+
+// transposed returns the transposition of the given square cells.
+func transposed(cells [][]rune) [][]rune {
+	transposition := make([][]rune, len(cells))
+	for row := range cells {
+		transposition[row] = make([]rune, len(cells))
+		for column := range cells {
+			transposition[row][column] = cells[column][row]
+		}
+	}
+	return transposition
+}
+
+// compareCells compares the given cells in reading order.
+func compareCells(a, b [][]rune) int {
+	return slices.CompareFunc(a, b, slices.Compare)
+}
@@ -0,0 +1,22 @@
+package crogo
+
+import (
+	"crogo/pkg/grid"
+	"unicode/utf8"
+)
+
+// Validate validates the given grid cells against the given words and returns a report containing all the issues
+// found. Unlike NewCrossword, it does not stop at errors and also reports warnings. See grid.Issue for the details
+// about the issues.
+func Validate(cells [][]rune, words []string) *grid.Report {
+	return grid.ValidateWithMaxWordLength(cells, maxLength(words))
+}
+
+// maxLength returns the length of the longest of the given words.
+func maxLength(words []string) int {
+	result := 0
+	for _, word := range words {
+		result = max(result, utf8.RuneCountInString(word))
+	}
+	return result
+}
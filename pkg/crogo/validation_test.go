@@ -0,0 +1,42 @@
+package crogo
+
+import (
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	words := []string{"ABC", "AB"}
+	cells := [][]rune{
+		{'.', '.', '.', '.'},
+		{'#', '#', '#', '#'},
+		{'.', '.', '#', '.'},
+	}
+
+	report := Validate(cells, words)
+
+	expectedIssues := []grid.Issue{
+		&grid.UncheckedCellError{Row: 2, Column: 3},
+		&grid.SlotTooLongError{Row: 0, Column: 0, Length: 4, MaxWordLength: 3},
+		&grid.UnreachableRegionError{Row: 2, Column: 0, CellCount: 2},
+		&grid.UnreachableRegionError{Row: 2, Column: 3, CellCount: 1},
+	}
+	assert.Equal(t, expectedIssues, report.Issues)
+	assert.Len(t, report.Errors(), 1)
+	assert.Len(t, report.Warnings(), 3)
+}
+
+func TestValidate_Valid(t *testing.T) {
+	words := []string{"ABC"}
+	cells := [][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	}
+
+	report := Validate(cells, words)
+
+	assert.Empty(t, report.Issues)
+	assert.NoError(t, report.Err())
+}
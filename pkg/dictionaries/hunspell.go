@@ -0,0 +1,315 @@
+package dictionaries
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// Hunspell flag types, as declared by the FLAG directive of an affix file. Default flag type is single character.
+const (
+	flagTypeLong   = "long"
+	flagTypeNumber = "num"
+)
+
+// affixRule is a single PFX or SFX rule of a Hunspell affix file.
+type affixRule struct {
+	// strip is the text removed from the stem before adding the affix.
+	strip string
+	// add is the affix itself.
+	add string
+	// condition is the pattern the stem must match for the rule to apply.
+	condition *regexp.Regexp
+}
+
+// affixClass is the group of rules sharing a flag.
+type affixClass struct {
+	isPrefix     bool
+	crossProduct bool
+	rules        []affixRule
+}
+
+// affixFile is the subset of a Hunspell affix file relevant to word expansion.
+type affixFile struct {
+	flagType      string
+	classes       map[string]*affixClass
+	needAffix     string
+	forbiddenWord string
+	decoder       *encoding.Decoder
+}
+
+// Hunspell returns the words of the given Hunspell dictionary, with affix rules of the given affix file expanded into
+// surface forms, as a slice of strings.
+//
+// Only the common subset of the affix file format is supported: PFX and SFX rules - including cross products of
+// prefixes and suffixes -, the SET, FLAG, NEEDAFFIX and FORBIDDENWORD directives. Continuation classes and compounding
+// rules are ignored.
+//
+// Words are normalized the same way as the builtin dictionaries: Words containing characters which cannot be mapped to
+// the alphabet are dropped, as are duplicates.
+func Hunspell(dic, aff io.Reader) ([]string, error) {
+	affixes, err := parseAffixFile(aff)
+	if err != nil {
+		return nil, fmt.Errorf("invalid affix file: %w", err)
+	}
+	words, err := affixes.expandDictionary(dic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dictionary file: %w", err)
+	}
+	return normalizeAll(words), nil
+}
+
+// parseAffixFile parses the given affix file.
+func parseAffixFile(aff io.Reader) (*affixFile, error) {
+	content, err := io.ReadAll(aff)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read: %w", err)
+	}
+	affixes := &affixFile{classes: make(map[string]*affixClass)}
+	if affixes.decoder, err = decoderFor(content); err != nil {
+		return nil, err
+	}
+	decodedContent, err := affixes.decoder.Bytes(content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(decodedContent))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "FLAG":
+			affixes.flagType = fields[1]
+		case "NEEDAFFIX":
+			affixes.needAffix = fields[1]
+		case "FORBIDDENWORD":
+			affixes.forbiddenWord = fields[1]
+		case "PFX", "SFX":
+			if err = affixes.parseAffixLine(fields); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read: %w", err)
+	}
+	return affixes, nil
+}
+
+// decoderFor returns the decoder corresponding to the encoding declared by the SET directive of the given affix file
+// content. Affix files without SET directive are assumed to be encoded in UTF-8.
+func decoderFor(content []byte) (*encoding.Decoder, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "SET" {
+			continue
+		}
+		// Hunspell names ISO-8859 encodings "ISO8859-x" and Windows code pages "microsoft-cp125x".
+		name := strings.TrimPrefix(strings.ToLower(fields[1]), "microsoft-")
+		enc, err := htmlindex.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported encoding %s: %w", fields[1], err)
+		}
+		return enc.NewDecoder(), nil
+	}
+	return encoding.Nop.NewDecoder(), nil
+}
+
+// parseAffixLine parses the given fields of a PFX or SFX line, which is either a class header or a rule.
+func (a *affixFile) parseAffixLine(fields []string) error {
+	isPrefix := fields[0] == "PFX"
+	flag := fields[1]
+	class, classExists := a.classes[flag]
+	if !classExists {
+		// Header: PFX|SFX flag cross_product number_of_rules
+		if len(fields) < 4 {
+			return fmt.Errorf("invalid %s header: %s", fields[0], strings.Join(fields, " "))
+		}
+		a.classes[flag] = &affixClass{isPrefix: isPrefix, crossProduct: fields[2] == "Y"}
+		return nil
+	}
+	// Rule: PFX|SFX flag stripping affix[/flags] [condition [morphological fields...]]
+	if len(fields) < 4 {
+		return fmt.Errorf("invalid %s rule: %s", fields[0], strings.Join(fields, " "))
+	}
+	strip := fields[2]
+	if strip == "0" {
+		strip = ""
+	}
+	add, _, _ := strings.Cut(fields[3], "/") // continuation classes are not supported
+	if add == "0" {
+		add = ""
+	}
+	condition := "."
+	if len(fields) >= 5 {
+		condition = fields[4]
+	}
+	conditionRegexp, err := compileCondition(condition, isPrefix)
+	if err != nil {
+		return err
+	}
+	class.rules = append(class.rules, affixRule{strip, add, conditionRegexp})
+	return nil
+}
+
+// compileCondition translates the given Hunspell affix condition to a regular expression anchored at the start of
+// the stem for prefixes or at the end of the stem for suffixes.
+func compileCondition(condition string, isPrefix bool) (*regexp.Regexp, error) {
+	var pattern strings.Builder
+	if isPrefix {
+		pattern.WriteRune('^')
+	}
+	inCharacterClass := false
+	for _, r := range condition {
+		switch {
+		case r == '[':
+			inCharacterClass = true
+			pattern.WriteRune(r)
+		case r == ']':
+			inCharacterClass = false
+			pattern.WriteRune(r)
+		case r == '.' && !inCharacterClass:
+			pattern.WriteRune(r)
+		case r == '^' && inCharacterClass:
+			pattern.WriteRune(r)
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if !isPrefix {
+		pattern.WriteRune('$')
+	}
+	conditionRegexp, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %s: %w", condition, err)
+	}
+	return conditionRegexp, nil
+}
+
+// expandDictionary reads the given dictionary file and returns all the words it describes.
+func (a *affixFile) expandDictionary(dic io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(a.decoder.Reader(dic))
+	var words []string
+	isFirstLine := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if isFirstLine {
+			isFirstLine = false
+			if _, err := strconv.Atoi(line); err == nil {
+				// Approximate word count, useless here
+				continue
+			}
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, a.expandEntry(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read: %w", err)
+	}
+	return words, nil
+}
+
+// expandEntry returns the words described by the given dictionary line, i.e. the stem and its affixed forms.
+func (a *affixFile) expandEntry(line string) []string {
+	// Morphological fields are separated from the word by a tab or a space
+	entry, _, _ := strings.Cut(strings.ReplaceAll(line, "\t", " "), " ")
+	stem, flagsField, _ := strings.Cut(entry, "/")
+	flags := a.parseFlags(flagsField)
+	if containsFlag(flags, a.forbiddenWord) {
+		return nil
+	}
+
+	var words []string
+	if !containsFlag(flags, a.needAffix) {
+		words = append(words, stem)
+	}
+	var prefixes, suffixes []*affixClass
+	for _, flag := range flags {
+		if class, ok := a.classes[flag]; ok {
+			if class.isPrefix {
+				prefixes = append(prefixes, class)
+			} else {
+				suffixes = append(suffixes, class)
+			}
+		}
+	}
+	for _, suffix := range suffixes {
+		suffixedWords := suffix.apply(stem)
+		words = append(words, suffixedWords...)
+		if !suffix.crossProduct {
+			continue
+		}
+		for _, prefix := range prefixes {
+			if prefix.crossProduct {
+				for _, suffixedWord := range suffixedWords {
+					words = append(words, prefix.apply(suffixedWord)...)
+				}
+			}
+		}
+	}
+	for _, prefix := range prefixes {
+		words = append(words, prefix.apply(stem)...)
+	}
+	return words
+}
+
+// parseFlags splits the given flag field according to the flag type of the affix file.
+func (a *affixFile) parseFlags(flagsField string) []string {
+	if flagsField == "" {
+		return nil
+	}
+	switch a.flagType {
+	case flagTypeLong:
+		var flags []string
+		runes := []rune(flagsField)
+		for i := 0; i+1 < len(runes); i += 2 {
+			flags = append(flags, string(runes[i:i+2]))
+		}
+		return flags
+	case flagTypeNumber:
+		return strings.Split(flagsField, ",")
+	default:
+		flags := make([]string, 0, len(flagsField))
+		for _, r := range flagsField {
+			flags = append(flags, string(r))
+		}
+		return flags
+	}
+}
+
+// containsFlag returns true iff the given flag is defined and part of the given flags.
+func containsFlag(flags []string, flag string) bool {
+	return flag != "" && slices.Contains(flags, flag)
+}
+
+// apply returns the words resulting from the application of the rules of this class to the given stem.
+func (c *affixClass) apply(stem string) []string {
+	var words []string
+	for _, rule := range c.rules {
+		if !rule.condition.MatchString(stem) {
+			continue
+		}
+		if c.isPrefix && strings.HasPrefix(stem, rule.strip) {
+			words = append(words, rule.add+strings.TrimPrefix(stem, rule.strip))
+		} else if !c.isPrefix && strings.HasSuffix(stem, rule.strip) {
+			words = append(words, strings.TrimSuffix(stem, rule.strip)+rule.add)
+		}
+	}
+	return words
+}
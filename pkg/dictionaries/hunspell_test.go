@@ -0,0 +1,75 @@
+package dictionaries
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHunspell(t *testing.T) {
+	dic, err := os.Open("testdata/sample.dic")
+	require.NoError(t, err)
+	defer dic.Close()
+	aff, err := os.Open("testdata/sample.aff")
+	require.NoError(t, err)
+	defer aff.Close()
+
+	words, err := Hunspell(dic, aff)
+
+	require.NoError(t, err)
+	expectedWords := []string{
+		"WORK", "WORKED", "REWORKED", "REWORK",
+		"TRY", "TRIED",
+		"PLAY", "PLAYS",
+		"BOX", "BOXES",
+		"KIND", "UNKIND",
+		"CAFE", "CAFES",
+	}
+	assert.Equal(t, expectedWords, words)
+}
+
+func TestHunspell_LongFlags(t *testing.T) {
+	aff := "FLAG long\nSFX Aa Y 1\nSFX Aa 0 s .\n"
+	dic := "2\ncat/Aa\ndog/Bb\n"
+
+	words, err := Hunspell(strings.NewReader(dic), strings.NewReader(aff))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CAT", "CATS", "DOG"}, words)
+}
+
+func TestHunspell_NumericFlags(t *testing.T) {
+	aff := "FLAG num\nPFX 101 N 1\nPFX 101 0 a .\nSFX 7 N 1\nSFX 7 e ing e\n"
+	dic := "bake/101,7\n"
+
+	words, err := Hunspell(strings.NewReader(dic), strings.NewReader(aff))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BAKE", "BAKING", "ABAKE"}, words)
+}
+
+func TestHunspell_Iso8859(t *testing.T) {
+	aff := "SET ISO8859-1\nSFX S Y 1\nSFX S 0 s .\n"
+	dic := "1\nna\xefve/S\n" // "naïve" in ISO-8859-1
+
+	words, err := Hunspell(strings.NewReader(dic), strings.NewReader(aff))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NAIVE", "NAIVES"}, words)
+}
+
+func TestHunspell_UnsupportedEncoding(t *testing.T) {
+	_, err := Hunspell(strings.NewReader(""), strings.NewReader("SET FOO-42\n"))
+
+	assert.ErrorContains(t, err, "unsupported encoding FOO-42")
+}
+
+func TestHunspell_InvalidRule(t *testing.T) {
+	aff := "SFX S Y 1\nSFX S 0\n"
+
+	_, err := Hunspell(strings.NewReader(""), strings.NewReader(aff))
+
+	assert.EqualError(t, err, "invalid affix file: line 2: invalid SFX rule: SFX S 0")
+}
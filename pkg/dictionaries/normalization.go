@@ -0,0 +1,64 @@
+package dictionaries
+
+import (
+	"crogo/internal/alphabet"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// cleaner is a string transformer that transform or remove any character that the crossword solver doesn't support.
+var cleaner = transform.Chain(norm.NFD,
+	runes.Remove(runes.In(unicode.Mn)),
+	runes.Remove(runes.In(unicode.Punct)),
+	runes.Remove(runes.In(unicode.Space)),
+	runes.Map(func(r rune) rune { return unicode.ToUpper(r) }),
+	norm.NFC)
+
+// ligatures maps the letters which are not decomposed by cleaner to their equivalent in the alphabet.
+var ligatures = strings.NewReplacer("Ø", "OE")
+
+// normalize returns the given text transformed so that it only contains characters supported by the crossword
+// solver, as far as possible.
+func normalize(text string) string {
+	cleanText, _, _ := transform.String(cleaner, text)
+	return ligatures.Replace(cleanText)
+}
+
+// normalizeAll normalizes the given words and returns the normalized words, without duplicates and without the words
+// which still contain characters outside the alphabet after normalization. Order of first occurrence is preserved.
+func normalizeAll(words []string) []string {
+	normalizedWords := make([]string, 0, len(words))
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		normalizedWord := normalize(word)
+		if normalizedWord == "" || !isInAlphabet(normalizedWord) {
+			continue
+		}
+		if _, alreadySeen := seen[normalizedWord]; alreadySeen {
+			continue
+		}
+		seen[normalizedWord] = struct{}{}
+		normalizedWords = append(normalizedWords, normalizedWord)
+	}
+	return slices.Clip(normalizedWords)
+}
+
+// isInAlphabet returns true iff all the letters of the given word are in the alphabet.
+func isInAlphabet(word string) bool {
+	for _, letter := range word {
+		if !alphabet.Contains(letter) {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalize returns the given word transformed the same way as the words of the dictionaries, e.g. "Crème brûlée"
+// becomes "CREMEBRULEE". Result may still contain characters outside the alphabet.
+func Normalize(word string) string {
+	return normalize(word)
+}
@@ -0,0 +1,115 @@
+// Package exolve writes crosswords in the Exolve format, a plain text puzzle specification rendered as a playable
+// puzzle by the Exolve HTML/JavaScript application.
+//
+// See https://github.com/viresh-ratnakar/exolve for the specification.
+package exolve
+
+import (
+	"crogo/pkg/grid"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Cell values of the Exolve grid.
+const (
+	exolveBlock = "."
+	// exolveUnknown is a light whose solution is not known.
+	exolveUnknown = "0"
+	// exolvePrefilled is the decorator of a letter given to the player.
+	exolvePrefilled = "!"
+)
+
+// scriptUrl is the location of the Exolve application loaded by the written HTML pages.
+const scriptUrl = "https://viresh-ratnakar.github.io/exolve-m"
+
+// htmlTemplate is the HTML page embedding a puzzle, which is given as a JavaScript template literal.
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8"/>
+<meta name="viewport" content="width=device-width, initial-scale=1"/>
+<link rel="stylesheet" type="text/css" href="%[1]s.css"/>
+<script src="%[1]s.js"></script>
+<title>Exolve</title>
+</head>
+<body>
+<script>
+createExolve(` + "`\n%[2]s`" + `);
+</script>
+</body>
+</html>
+`
+
+// Write writes the given solution of the given grid as an Exolve puzzle specification, from "exolve-begin" to
+// "exolve-end".
+//
+// Letters of the input grid are written as prefilled cells. A nil solution writes the grid itself, i.e. a puzzle
+// without solution. Clues are obtained from the given clue function; If nil, clues only contain the enumeration.
+func Write(w io.Writer, g *grid.Grid, solution [][]rune, clueOf func(grid.Entry) string) error {
+	if _, err := io.WriteString(w, specification(g, solution, clueOf)); err != nil {
+		return fmt.Errorf("cannot write exolve: %w", err)
+	}
+	return nil
+}
+
+// WriteHtml writes the given solution of the given grid as a standalone HTML page, playable in a browser. See Write
+// for the details.
+func WriteHtml(w io.Writer, g *grid.Grid, solution [][]rune, clueOf func(grid.Entry) string) error {
+	// Specification is embedded in a JavaScript template literal inside a script element
+	escaped := strings.NewReplacer(`\`, `\\`, "`", "\\`", "${", `\${`, "</", `<\/`).
+		Replace(specification(g, solution, clueOf))
+	if _, err := fmt.Fprintf(w, htmlTemplate, scriptUrl, escaped); err != nil {
+		return fmt.Errorf("cannot write exolve: %w", err)
+	}
+	return nil
+}
+
+// specification returns the Exolve specification of the given solution of the given grid.
+func specification(g *grid.Grid, solution [][]rune, clueOf func(grid.Entry) string) string {
+	if solution == nil {
+		solution = g.Cells()
+	}
+	var builder strings.Builder
+	builder.WriteString("exolve-begin\n")
+	fmt.Fprintf(&builder, "  exolve-width: %d\n", g.ColumnCount())
+	fmt.Fprintf(&builder, "  exolve-height: %d\n", g.RowCount())
+	builder.WriteString("  exolve-grid:\n")
+	for row := range g.RowCount() {
+		cells := make([]string, g.ColumnCount())
+		for column := range g.ColumnCount() {
+			cells[column] = cell(g.LetterAt(row, column), solution[row][column])
+		}
+		fmt.Fprintf(&builder, "    %s\n", strings.Join(cells, " "))
+	}
+	entries := g.Entries(solution)
+	for _, direction := range []grid.Direction{grid.Across, grid.Down} {
+		fmt.Fprintf(&builder, "  exolve-%s:\n", strings.ToLower(direction.String()))
+		for _, entry := range entries {
+			if entry.Direction() != direction {
+				continue
+			}
+			text := ""
+			if clueOf != nil {
+				text = clueOf(entry) + " "
+			}
+			fmt.Fprintf(&builder, "    %d %s(%d)\n", entry.Number(), text, entry.Length())
+		}
+	}
+	builder.WriteString("exolve-end\n")
+	return builder.String()
+}
+
+// cell returns the Exolve grid cell corresponding to the given input letter and solution letter.
+func cell(inputLetter, solutionLetter rune) string {
+	switch {
+	case inputLetter == grid.CellBlock:
+		return exolveBlock
+	case inputLetter != grid.CellEmpty:
+		return string(inputLetter) + exolvePrefilled
+	case solutionLetter == grid.CellEmpty:
+		return exolveUnknown
+	default:
+		return string(solutionLetter)
+	}
+}
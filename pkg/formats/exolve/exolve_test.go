@@ -0,0 +1,105 @@
+package exolve
+
+import (
+	"bytes"
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B', '#'},
+		{'C', 'D', 'E'},
+	}
+	var output bytes.Buffer
+
+	err = Write(&output, g, solution, func(entry grid.Entry) string { return "Clue for " + entry.Word() })
+
+	require.NoError(t, err)
+	expected := `exolve-begin
+  exolve-width: 3
+  exolve-height: 2
+  exolve-grid:
+    A! B .
+    C D E
+  exolve-across:
+    1 Clue for AB (2)
+    3 Clue for CDE (3)
+  exolve-down:
+    1 Clue for AC (2)
+    2 Clue for BD (2)
+exolve-end
+`
+	assert.Equal(t, expected, output.String())
+}
+
+func TestWrite_Unsolved(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.'},
+		{'.', '#'},
+	})
+	require.NoError(t, err)
+	var output bytes.Buffer
+
+	err = Write(&output, g, nil, nil)
+
+	require.NoError(t, err)
+	expected := `exolve-begin
+  exolve-width: 2
+  exolve-height: 2
+  exolve-grid:
+    A! 0
+    0 .
+  exolve-across:
+    1 (2)
+  exolve-down:
+    1 (2)
+exolve-end
+`
+	assert.Equal(t, expected, output.String())
+}
+
+func TestWriteHtml(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'.', '.'},
+	})
+	require.NoError(t, err)
+	var output bytes.Buffer
+
+	err = WriteHtml(&output, g, [][]rune{{'O', 'K'}}, func(grid.Entry) string { return "`${x}` </script>" })
+
+	require.NoError(t, err)
+	expected := "<!DOCTYPE html>\n" +
+		"<html lang=\"en\">\n" +
+		"<head>\n" +
+		"<meta charset=\"utf-8\"/>\n" +
+		"<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\"/>\n" +
+		"<link rel=\"stylesheet\" type=\"text/css\" href=\"https://viresh-ratnakar.github.io/exolve-m.css\"/>\n" +
+		"<script src=\"https://viresh-ratnakar.github.io/exolve-m.js\"></script>\n" +
+		"<title>Exolve</title>\n" +
+		"</head>\n" +
+		"<body>\n" +
+		"<script>\n" +
+		"createExolve(`\n" +
+		"exolve-begin\n" +
+		"  exolve-width: 2\n" +
+		"  exolve-height: 1\n" +
+		"  exolve-grid:\n" +
+		"    O K\n" +
+		"  exolve-across:\n" +
+		"    1 \\`\\${x}\\` <\\/script> (2)\n" +
+		"  exolve-down:\n" +
+		"exolve-end\n" +
+		"`);\n" +
+		"</script>\n" +
+		"</body>\n" +
+		"</html>\n"
+	assert.Equal(t, expected, output.String())
+}
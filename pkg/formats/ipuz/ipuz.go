@@ -0,0 +1,196 @@
+// Package ipuz reads and writes crosswords in the ipuz format, the open JSON standard for puzzles.
+//
+// See http://ipuz.org/ for the specification.
+package ipuz
+
+import (
+	"bytes"
+	"crogo/pkg/grid"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// version is the version of the ipuz specification written.
+	version = "http://ipuz.org/v2"
+	// kind is the kind of puzzle written.
+	kind = "http://ipuz.org/crossword#1"
+	// defaultBlock is the default value of a block, in puzzle and solution.
+	defaultBlock = "#"
+)
+
+// document is the subset of the ipuz crossword document relevant to crogo.
+type document struct {
+	Version    string     `json:"version"`
+	Kind       []string   `json:"kind"`
+	Dimensions dimensions `json:"dimensions"`
+	Block      string     `json:"block,omitempty"`
+	Puzzle     [][]any    `json:"puzzle"`
+	Solution   [][]any    `json:"solution,omitempty"`
+	// Clues are only written, in the form of clue: Read ignores them, since they may take other forms.
+	Clues json.RawMessage `json:"clues,omitempty"`
+}
+
+type dimensions struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// clue is an ipuz clue in its simplest form, i.e. a pair [number, text].
+type clue [2]any
+
+// Read reads the given ipuz crossword and returns the cells of the corresponding grid.
+//
+// Blocks and omitted cells are converted to grid.CellBlock. Letters given in the "solution" - or as "value" of a puzzle
+// cell - are kept as prefilled letters. Other cells are grid.CellEmpty.
+func Read(r io.Reader) ([][]rune, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ipuz: %w", err)
+	}
+	var doc document
+	if err = json.Unmarshal(stripJsonp(content), &doc); err != nil {
+		return nil, fmt.Errorf("invalid ipuz: %w", err)
+	}
+	block := doc.Block
+	if block == "" {
+		block = defaultBlock
+	}
+	width, height := doc.Dimensions.Width, doc.Dimensions.Height
+	if len(doc.Puzzle) != height {
+		return nil, fmt.Errorf("invalid ipuz: puzzle has %v rows but height is %v", len(doc.Puzzle), height)
+	}
+	cells := make([][]rune, height)
+	for row := range height {
+		if len(doc.Puzzle[row]) != width {
+			return nil, fmt.Errorf("invalid ipuz: puzzle row #%v has %v cells but width is %v", row,
+				len(doc.Puzzle[row]), width)
+		}
+		cells[row] = make([]rune, width)
+		for column := range width {
+			cell, err := readCell(doc.Puzzle[row][column], cellAt(doc.Solution, row, column), block)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ipuz: row #%v, column #%v: %w", row, column, err)
+			}
+			cells[row][column] = cell
+		}
+	}
+	return cells, nil
+}
+
+// stripJsonp removes the "ipuz(...)" JSONP wrapper that some ipuz files have.
+func stripJsonp(content []byte) []byte {
+	trimmed := bytes.TrimSpace(content)
+	if bytes.HasPrefix(trimmed, []byte("ipuz(")) && bytes.HasSuffix(trimmed, []byte(")")) {
+		return trimmed[len("ipuz(") : len(trimmed)-1]
+	}
+	return trimmed
+}
+
+// cellAt returns the cell at the given position of the given optional solution, or nil if not available.
+func cellAt(solution [][]any, row, column int) any {
+	if row >= len(solution) || column >= len(solution[row]) {
+		return nil
+	}
+	return solution[row][column]
+}
+
+// readCell converts the given ipuz puzzle cell and its solution to a grid cell.
+func readCell(puzzleCell, solutionCell any, block string) (rune, error) {
+	if puzzleCell == nil {
+		// Omitted cell
+		return grid.CellBlock, nil
+	}
+	var givenValue any
+	if puzzleCellObject, isObject := puzzleCell.(map[string]any); isObject {
+		puzzleCell = puzzleCellObject["cell"]
+		givenValue = puzzleCellObject["value"]
+	}
+	if label, isString := puzzleCell.(string); isString && label == block {
+		return grid.CellBlock, nil
+	}
+	for _, value := range []any{givenValue, solutionCell} {
+		if valueObject, isObject := value.(map[string]any); isObject {
+			value = valueObject["value"]
+		}
+		letter, isString := value.(string)
+		if !isString || letter == "" || letter == block {
+			continue
+		}
+		if utf8.RuneCountInString(letter) > 1 {
+			return 0, fmt.Errorf("unsupported multi-letter value %s", letter)
+		}
+		r, _ := utf8.DecodeRuneInString(strings.ToUpper(letter))
+		return r, nil
+	}
+	return grid.CellEmpty, nil
+}
+
+// Write writes the given solution of the given grid as an ipuz crossword.
+//
+// Cells are numbered following the grid slots. Letters of the input grid are written as given values of the puzzle,
+// so that they are displayed to the player. A nil solution writes the grid itself, i.e. an unsolved puzzle. Clues
+// are obtained from the given clue function; If nil, clues are left empty.
+func Write(w io.Writer, g *grid.Grid, solution [][]rune, clueOf func(grid.Entry) string) error {
+	if solution == nil {
+		solution = g.Cells()
+	}
+	numbers := g.ClueNumbers()
+	doc := document{
+		Version:    version,
+		Kind:       []string{kind},
+		Dimensions: dimensions{g.ColumnCount(), g.RowCount()},
+		Block:      defaultBlock,
+		Puzzle:     make([][]any, g.RowCount()),
+		Solution:   make([][]any, g.RowCount()),
+	}
+	for row := range g.RowCount() {
+		doc.Puzzle[row] = make([]any, g.ColumnCount())
+		doc.Solution[row] = make([]any, g.ColumnCount())
+		for column := range g.ColumnCount() {
+			doc.Puzzle[row][column], doc.Solution[row][column] = writeCell(g, solution, numbers, row, column)
+		}
+	}
+	clues := map[string][]clue{"Across": {}, "Down": {}}
+	for _, entry := range g.Entries(solution) {
+		text := ""
+		if clueOf != nil {
+			text = clueOf(entry)
+		}
+		direction := entry.Direction().String()
+		clues[direction] = append(clues[direction], clue{entry.Number(), text})
+	}
+	var err error
+	if doc.Clues, err = json.Marshal(clues); err != nil {
+		return fmt.Errorf("cannot write ipuz: %w", err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(doc); err != nil {
+		return fmt.Errorf("cannot write ipuz: %w", err)
+	}
+	return nil
+}
+
+// writeCell returns the puzzle cell and the solution cell at the given position.
+func writeCell(g *grid.Grid, solution [][]rune, numbers map[grid.Pos]int, row, column int) (any, any) {
+	inputLetter := g.LetterAt(row, column)
+	if inputLetter == grid.CellBlock {
+		return defaultBlock, defaultBlock
+	}
+	var puzzleCell any = 0
+	if number, numbered := numbers[grid.NewPos(column, row)]; numbered {
+		puzzleCell = number
+	}
+	if inputLetter != grid.CellEmpty {
+		puzzleCell = map[string]any{"cell": puzzleCell, "value": string(inputLetter)}
+	}
+	var solutionCell any
+	if solutionLetter := solution[row][column]; solutionLetter != grid.CellEmpty {
+		solutionCell = string(solutionLetter)
+	}
+	return puzzleCell, solutionCell
+}
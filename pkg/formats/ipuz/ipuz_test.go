@@ -0,0 +1,168 @@
+package ipuz
+
+import (
+	"bytes"
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	file, err := os.Open("testdata/sample.ipuz")
+	require.NoError(t, err)
+	defer file.Close()
+
+	cells, err := Read(file)
+
+	require.NoError(t, err)
+	expectedCells := [][]rune{
+		{'A', '.', '#', '#'},
+		{'.', 'B', '.', '.'},
+		{'#', '.', 'E', '.'},
+	}
+	assert.Equal(t, expectedCells, cells)
+}
+
+func TestRead_Jsonp(t *testing.T) {
+	content := `ipuz({"dimensions":{"width":2,"height":1},"puzzle":[[1,0]],"solution":[["O","K"]]})`
+
+	cells, err := Read(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]rune{{'O', 'K'}}, cells)
+}
+
+func TestRead_ClueForms(t *testing.T) {
+	// Clues take the forms allowed by the specification: objects, plain strings and [number, text] pairs
+	content := `{"dimensions":{"width":2,"height":1},"puzzle":[[1,0]],"solution":[["O","K"]],"clues":{
+		"Across":[{"number":1,"clue":"Fine","enumeration":"2"}],
+		"Down":["Not numbered",[1,"Fine"]]}}`
+
+	cells, err := Read(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]rune{{'O', 'K'}}, cells)
+}
+
+func TestRead_CustomBlock(t *testing.T) {
+	content := `{"dimensions":{"width":2,"height":1},"block":"X","puzzle":[["X",0]]}`
+
+	cells, err := Read(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]rune{{'#', '.'}}, cells)
+}
+
+func TestRead_InvalidDimensions(t *testing.T) {
+	content := `{"dimensions":{"width":3,"height":1},"puzzle":[[1,0]]}`
+
+	_, err := Read(strings.NewReader(content))
+
+	assert.EqualError(t, err, "invalid ipuz: puzzle row #0 has 2 cells but width is 3")
+}
+
+func TestRead_Rebus(t *testing.T) {
+	content := `{"dimensions":{"width":2,"height":1},"puzzle":[[1,0]],"solution":[["OK","K"]]}`
+
+	_, err := Read(strings.NewReader(content))
+
+	assert.EqualError(t, err, "invalid ipuz: row #0, column #0: unsupported multi-letter value OK")
+}
+
+func TestWrite(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B', '#'},
+		{'C', 'D', 'E'},
+	}
+	var output bytes.Buffer
+
+	err = Write(&output, g, solution, func(entry grid.Entry) string { return "Clue for " + entry.Word() })
+
+	require.NoError(t, err)
+	expected := `{
+  "version": "http://ipuz.org/v2",
+  "kind": [
+    "http://ipuz.org/crossword#1"
+  ],
+  "dimensions": {
+    "width": 3,
+    "height": 2
+  },
+  "block": "#",
+  "puzzle": [
+    [
+      {
+        "cell": 1,
+        "value": "A"
+      },
+      2,
+      "#"
+    ],
+    [
+      3,
+      0,
+      0
+    ]
+  ],
+  "solution": [
+    [
+      "A",
+      "B",
+      "#"
+    ],
+    [
+      "C",
+      "D",
+      "E"
+    ]
+  ],
+  "clues": {
+    "Across": [
+      [
+        1,
+        "Clue for AB"
+      ],
+      [
+        3,
+        "Clue for CDE"
+      ]
+    ],
+    "Down": [
+      [
+        1,
+        "Clue for AC"
+      ],
+      [
+        2,
+        "Clue for BD"
+      ]
+    ]
+  }
+}
+`
+	assert.Equal(t, expected, output.String())
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	var output bytes.Buffer
+
+	err = Write(&output, g, nil, nil)
+	require.NoError(t, err)
+	cells, err := Read(&output)
+
+	require.NoError(t, err)
+	assert.Equal(t, g.Cells(), cells)
+}
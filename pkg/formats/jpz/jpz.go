@@ -0,0 +1,277 @@
+// Package jpz reads and writes crosswords in the JPZ format, the zipped XML format of Crossword Compiler.
+//
+// A JPZ file is a zip archive containing a single XML document, although plain XML documents are commonly found with
+// the same extension. Both are accepted by Read; Write produces the zipped form.
+package jpz
+
+import (
+	"archive/zip"
+	"bytes"
+	"crogo/pkg/grid"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// rootElement is the name of the written root element.
+	rootElement = "crossword-compiler-applet"
+	// appletNamespace is the namespace of the root element.
+	appletNamespace = "http://crossword.info/xml/crossword-compiler-applet"
+	// puzzleNamespace is the namespace of the puzzle element.
+	puzzleNamespace = "http://crossword.info/xml/rectangular-puzzle"
+	// entryName is the name of the XML document inside the written archives.
+	entryName = "crossword.xml"
+)
+
+// Cell types. Cells without type are letter cells.
+const (
+	cellTypeBlock = "block"
+	cellTypeVoid  = "void"
+	cellTypeClue  = "clue"
+)
+
+// zipMagic is the signature starting zip archives.
+var zipMagic = []byte("PK\x03\x04")
+
+// document is the subset of the Crossword Compiler document relevant to crogo. Its root element is either
+// "crossword-compiler-applet" or "crossword-compiler".
+type document struct {
+	XMLName xml.Name
+	Xmlns   string            `xml:"xmlns,attr,omitempty"`
+	Puzzle  rectangularPuzzle `xml:"rectangular-puzzle"`
+}
+
+type rectangularPuzzle struct {
+	Xmlns     string    `xml:"xmlns,attr,omitempty"`
+	Crossword crossword `xml:"crossword"`
+}
+
+type crossword struct {
+	Grid  xmlGrid `xml:"grid"`
+	Words []word  `xml:"word"`
+	Clues []clues `xml:"clues"`
+}
+
+type xmlGrid struct {
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Cells  []cell `xml:"cell"`
+}
+
+type cell struct {
+	X          int    `xml:"x,attr"`
+	Y          int    `xml:"y,attr"`
+	Type       string `xml:"type,attr,omitempty"`
+	Solution   string `xml:"solution,attr,omitempty"`
+	Number     string `xml:"number,attr,omitempty"`
+	Hint       bool   `xml:"hint,attr,omitempty"`
+	SolveState string `xml:"solve-state,attr,omitempty"`
+	LeftBar    bool   `xml:"left-bar,attr,omitempty"`
+	TopBar     bool   `xml:"top-bar,attr,omitempty"`
+	RightBar   bool   `xml:"right-bar,attr,omitempty"`
+	BottomBar  bool   `xml:"bottom-bar,attr,omitempty"`
+	Shape      string `xml:"background-shape,attr,omitempty"`
+}
+
+// word is a sequence of cells, designated by 1-based coordinates or ranges of coordinates, e.g. x="1-3" y="2".
+type word struct {
+	Id int    `xml:"id,attr"`
+	X  string `xml:"x,attr"`
+	Y  string `xml:"y,attr"`
+}
+
+type clues struct {
+	Title string `xml:"title>b"`
+	Clues []clue `xml:"clue"`
+}
+
+type clue struct {
+	Word   int    `xml:"word,attr"`
+	Number int    `xml:"number,attr"`
+	Format string `xml:"format,attr,omitempty"`
+	Text   string `xml:",chardata"`
+}
+
+// Read reads the given JPZ crossword, zipped or not, and returns the cells of the corresponding grid.
+//
+// Blocks, void cells and clue cells are converted to grid.CellBlock. Fixed letters - i.e. hinted cells, whose solution
+// is given to the player, and cells with a solve state - are kept as prefilled letters. Other cells are grid.CellEmpty.
+// Circles and other background shapes are ignored; Bars are not supported.
+func Read(r io.Reader) ([][]rune, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read jpz: %w", err)
+	}
+	if bytes.HasPrefix(content, zipMagic) {
+		if content, err = unzip(content); err != nil {
+			return nil, err
+		}
+	}
+	var doc document
+	if err = xml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("invalid jpz: %w", err)
+	}
+	xmlGrid := doc.Puzzle.Crossword.Grid
+	if xmlGrid.Width <= 0 || xmlGrid.Height <= 0 {
+		return nil, fmt.Errorf("invalid jpz: bad grid dimensions %vx%v", xmlGrid.Width, xmlGrid.Height)
+	}
+	cells := make([][]rune, xmlGrid.Height)
+	for row := range cells {
+		// Cells not described are not part of the grid
+		cells[row] = []rune(strings.Repeat(string(grid.CellBlock), xmlGrid.Width))
+	}
+	for _, xmlCell := range xmlGrid.Cells {
+		row, column := xmlCell.Y-1, xmlCell.X-1
+		if row < 0 || row >= xmlGrid.Height || column < 0 || column >= xmlGrid.Width {
+			return nil, fmt.Errorf("invalid jpz: cell (%v, %v) is outside the grid", xmlCell.X, xmlCell.Y)
+		}
+		value, err := readCell(xmlCell)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jpz: row #%v, column #%v: %w", row, column, err)
+		}
+		cells[row][column] = value
+	}
+	return cells, nil
+}
+
+// unzip returns the content of the XML document of the given JPZ archive.
+func unzip(content []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid jpz: %w", err)
+	}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		entry, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("invalid jpz: %w", err)
+		}
+		defer entry.Close()
+		document, err := io.ReadAll(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jpz: %w", err)
+		}
+		return document, nil
+	}
+	return nil, errors.New("invalid jpz: empty archive")
+}
+
+// readCell converts the given JPZ cell to a grid cell.
+func readCell(xmlCell cell) (rune, error) {
+	switch xmlCell.Type {
+	case cellTypeBlock, cellTypeVoid, cellTypeClue:
+		return grid.CellBlock, nil
+	case "":
+		// Letter cell
+	default:
+		return 0, fmt.Errorf("unsupported cell type %s", xmlCell.Type)
+	}
+	if xmlCell.LeftBar || xmlCell.TopBar || xmlCell.RightBar || xmlCell.BottomBar {
+		return 0, errors.New("unsupported bars")
+	}
+	letter := xmlCell.SolveState
+	if xmlCell.Hint {
+		letter = xmlCell.Solution
+	}
+	if letter == "" {
+		return grid.CellEmpty, nil
+	}
+	if utf8.RuneCountInString(letter) > 1 {
+		return 0, fmt.Errorf("unsupported multi-letter value %s", letter)
+	}
+	r, _ := utf8.DecodeRuneInString(strings.ToUpper(letter))
+	return r, nil
+}
+
+// Write writes the given solution of the given grid as a zipped JPZ crossword.
+//
+// Cells are numbered following the grid slots and each slot is written as a word. Letters of the input grid are
+// written as hints, so that they are displayed to the player. A nil solution writes the grid itself, i.e. a puzzle
+// without solution. Clues are obtained from the given clue function; If nil, clues are left empty.
+func Write(w io.Writer, g *grid.Grid, solution [][]rune, clueOf func(grid.Entry) string) error {
+	if solution == nil {
+		solution = g.Cells()
+	}
+	numbers := g.ClueNumbers()
+	doc := document{XMLName: xml.Name{Local: rootElement}, Xmlns: appletNamespace, Puzzle: rectangularPuzzle{Xmlns: puzzleNamespace}}
+	xmlCrossword := &doc.Puzzle.Crossword
+	xmlCrossword.Grid = xmlGrid{Width: g.ColumnCount(), Height: g.RowCount()}
+	for row := range g.RowCount() {
+		for column := range g.ColumnCount() {
+			xmlCrossword.Grid.Cells = append(xmlCrossword.Grid.Cells, writeCell(g, solution, numbers, row, column))
+		}
+	}
+	cluesByDirection := map[grid.Direction]*clues{
+		grid.Across: {Title: grid.Across.String()},
+		grid.Down:   {Title: grid.Down.String()},
+	}
+	for i, entry := range g.Entries(solution) {
+		id := i + 1
+		xmlCrossword.Words = append(xmlCrossword.Words, writeWord(id, entry.Slot))
+		text := ""
+		if clueOf != nil {
+			text = clueOf(entry)
+		}
+		directionClues := cluesByDirection[entry.Direction()]
+		directionClues.Clues = append(directionClues.Clues,
+			clue{Word: id, Number: entry.Number(), Format: strconv.Itoa(entry.Length()), Text: text})
+	}
+	xmlCrossword.Clues = []clues{*cluesByDirection[grid.Across], *cluesByDirection[grid.Down]}
+
+	content, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot write jpz: %w", err)
+	}
+	archive := zip.NewWriter(w)
+	entry, err := archive.Create(entryName)
+	if err != nil {
+		return fmt.Errorf("cannot write jpz: %w", err)
+	}
+	if _, err = entry.Write(append([]byte(xml.Header), content...)); err != nil {
+		return fmt.Errorf("cannot write jpz: %w", err)
+	}
+	if err = archive.Close(); err != nil {
+		return fmt.Errorf("cannot write jpz: %w", err)
+	}
+	return nil
+}
+
+// writeCell returns the JPZ cell at the given position.
+func writeCell(g *grid.Grid, solution [][]rune, numbers map[grid.Pos]int, row, column int) cell {
+	xmlCell := cell{X: column + 1, Y: row + 1}
+	inputLetter := g.LetterAt(row, column)
+	if inputLetter == grid.CellBlock {
+		xmlCell.Type = cellTypeBlock
+		return xmlCell
+	}
+	if number, numbered := numbers[grid.NewPos(column, row)]; numbered {
+		xmlCell.Number = strconv.Itoa(number)
+	}
+	if solutionLetter := solution[row][column]; solutionLetter != grid.CellEmpty {
+		xmlCell.Solution = string(solutionLetter)
+	}
+	xmlCell.Hint = inputLetter != grid.CellEmpty
+	return xmlCell
+}
+
+// writeWord returns the JPZ word corresponding to the given slot.
+func writeWord(id int, slot grid.Slot) word {
+	positions := slot.Positions()
+	first, last := positions[0], positions[len(positions)-1]
+	return word{Id: id, X: coordinates(first.Column(), last.Column()), Y: coordinates(first.Row(), last.Row())}
+}
+
+// coordinates returns the 1-based JPZ representation of the given 0-based coordinate range.
+func coordinates(first, last int) string {
+	if first == last {
+		return strconv.Itoa(first + 1)
+	}
+	return fmt.Sprintf("%d-%d", first+1, last+1)
+}
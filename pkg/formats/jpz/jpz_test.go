@@ -0,0 +1,140 @@
+package jpz
+
+import (
+	"archive/zip"
+	"bytes"
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	for _, fixture := range []string{"testdata/sample.jpz", "testdata/sample.xml"} {
+		t.Run(fixture, func(t *testing.T) {
+			file, err := os.Open(fixture)
+			require.NoError(t, err)
+			defer file.Close()
+
+			cells, err := Read(file)
+
+			require.NoError(t, err)
+			expectedCells := [][]rune{
+				{'A', '.', '#', '#'},
+				{'.', 'D', '.', '.'},
+				{'#', '.', '.', '#'},
+			}
+			assert.Equal(t, expectedCells, cells)
+		})
+	}
+}
+
+func TestRead_Bars(t *testing.T) {
+	content := `<crossword-compiler><rectangular-puzzle><crossword><grid width="2" height="1">
+<cell x="1" y="1"/><cell x="2" y="1" left-bar="true"/></grid></crossword></rectangular-puzzle></crossword-compiler>`
+
+	_, err := Read(strings.NewReader(content))
+
+	assert.EqualError(t, err, "invalid jpz: row #0, column #1: unsupported bars")
+}
+
+func TestRead_OutsideGrid(t *testing.T) {
+	content := `<crossword-compiler><rectangular-puzzle><crossword><grid width="1" height="1">
+<cell x="2" y="1"/></grid></crossword></rectangular-puzzle></crossword-compiler>`
+
+	_, err := Read(strings.NewReader(content))
+
+	assert.EqualError(t, err, "invalid jpz: cell (2, 1) is outside the grid")
+}
+
+func TestRead_Rebus(t *testing.T) {
+	content := `<crossword-compiler><rectangular-puzzle><crossword><grid width="1" height="1">
+<cell x="1" y="1" solution="OK" hint="true"/></grid></crossword></rectangular-puzzle></crossword-compiler>`
+
+	_, err := Read(strings.NewReader(content))
+
+	assert.EqualError(t, err, "invalid jpz: row #0, column #0: unsupported multi-letter value OK")
+}
+
+func TestWrite(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B', '#'},
+		{'C', 'D', 'E'},
+	}
+	var output bytes.Buffer
+
+	err = Write(&output, g, solution, func(entry grid.Entry) string { return "Clue for " + entry.Word() })
+
+	require.NoError(t, err)
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<crossword-compiler-applet xmlns="http://crossword.info/xml/crossword-compiler-applet">
+  <rectangular-puzzle xmlns="http://crossword.info/xml/rectangular-puzzle">
+    <crossword>
+      <grid width="3" height="2">
+        <cell x="1" y="1" solution="A" number="1" hint="true"></cell>
+        <cell x="2" y="1" solution="B" number="2"></cell>
+        <cell x="3" y="1" type="block"></cell>
+        <cell x="1" y="2" solution="C" number="3"></cell>
+        <cell x="2" y="2" solution="D"></cell>
+        <cell x="3" y="2" solution="E"></cell>
+      </grid>
+      <word id="1" x="1-2" y="1"></word>
+      <word id="2" x="1-3" y="2"></word>
+      <word id="3" x="1" y="1-2"></word>
+      <word id="4" x="2" y="1-2"></word>
+      <clues>
+        <title>
+          <b>Across</b>
+        </title>
+        <clue word="1" number="1" format="2">Clue for AB</clue>
+        <clue word="2" number="3" format="3">Clue for CDE</clue>
+      </clues>
+      <clues>
+        <title>
+          <b>Down</b>
+        </title>
+        <clue word="3" number="1" format="2">Clue for AC</clue>
+        <clue word="4" number="2" format="2">Clue for BD</clue>
+      </clues>
+    </crossword>
+  </rectangular-puzzle>
+</crossword-compiler-applet>`
+	assert.Equal(t, expected, unzipped(t, output.Bytes()))
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	var output bytes.Buffer
+
+	err = Write(&output, g, nil, nil)
+	require.NoError(t, err)
+	cells, err := Read(&output)
+
+	require.NoError(t, err)
+	assert.Equal(t, g.Cells(), cells)
+}
+
+// unzipped returns the content of the single entry of the given archive.
+func unzipped(t *testing.T, archive []byte) string {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	require.Len(t, reader.File, 1)
+	entry, err := reader.File[0].Open()
+	require.NoError(t, err)
+	defer entry.Close()
+	content, err := io.ReadAll(entry)
+	require.NoError(t, err)
+	return string(content)
+}
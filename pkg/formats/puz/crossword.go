@@ -0,0 +1,70 @@
+package puz
+
+import (
+	"cmp"
+	"crogo/pkg/grid"
+	"io"
+	"slices"
+)
+
+// Read reads the given .puz crossword and returns the cells of the corresponding grid, see Puzzle.Cells.
+func Read(r io.Reader) ([][]rune, error) {
+	puzzle, err := Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	return puzzle.Cells()
+}
+
+// Cells returns the cells of the grid corresponding to this puzzle: Blocks and letters of the solution are kept.
+// Rebus squares contain the first letter of their rebus solution.
+//
+// Function returns ErrScrambled if the solution is scrambled.
+func (p *Puzzle) Cells() ([][]rune, error) {
+	if p.ScrambledTag != 0 {
+		return nil, ErrScrambled
+	}
+	cells := make([][]rune, len(p.Solution))
+	for i, row := range p.Solution {
+		cells[i] = slices.Clone(row)
+	}
+	return cells, nil
+}
+
+// Write writes the given solution of the given grid as a .puz crossword, see New.
+func Write(w io.Writer, g *grid.Grid, solution [][]rune, clueOf func(grid.Entry) string) error {
+	return New(g, solution, clueOf).Encode(w)
+}
+
+// New creates a new puzzle from the given solution of the given grid.
+//
+// The letters of the input grid are put in the player's fill, so that they are displayed. A nil solution creates a
+// puzzle with an unknown solution, which Across Lite does not accept but other tools may. Clues are obtained from the
+// given clue function; If nil, clues are left empty.
+func New(g *grid.Grid, solution [][]rune, clueOf func(grid.Entry) string) *Puzzle {
+	if solution == nil {
+		solution = g.Cells()
+	}
+	entries := g.Entries(solution)
+	// .puz clues are ordered by number, across before down
+	slices.SortStableFunc(entries, func(a, b grid.Entry) int {
+		return cmp.Or(cmp.Compare(a.Number(), b.Number()), cmp.Compare(a.Direction(), b.Direction()))
+	})
+	clues := make([]string, len(entries))
+	if clueOf != nil {
+		for i, entry := range entries {
+			clues[i] = clueOf(entry)
+		}
+	}
+	puzzle := &Puzzle{
+		Version:    defaultVersion,
+		Solution:   make([][]rune, len(solution)),
+		Fill:       g.Cells(),
+		Clues:      clues,
+		PuzzleType: puzzleTypeNormal,
+	}
+	for i, row := range solution {
+		puzzle.Solution[i] = slices.Clone(row)
+	}
+	return puzzle
+}
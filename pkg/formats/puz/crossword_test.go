@@ -0,0 +1,101 @@
+package puz
+
+import (
+	"bytes"
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	file, err := os.Open("testdata/rebus.puz")
+	require.NoError(t, err)
+	defer file.Close()
+
+	cells, err := Read(file)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]rune{{'H', 'E'}, {'A', 'T'}}, cells)
+}
+
+func TestRead_Scrambled(t *testing.T) {
+	puzzle := &Puzzle{Version: "1.3", Solution: [][]rune{{'A'}}, Fill: [][]rune{{'.'}}, ScrambledTag: 4}
+	var content bytes.Buffer
+	require.NoError(t, puzzle.Encode(&content))
+
+	_, err := Read(&content)
+
+	assert.ErrorIs(t, err, ErrScrambled)
+}
+
+func TestNew(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B', '#'},
+		{'W', 'A', 'X'},
+		{'#', 'A', 'I'},
+	}
+
+	puzzle := New(g, solution, func(entry grid.Entry) string { return entry.String() + " " + entry.Word() })
+
+	assert.Equal(t, solution, puzzle.Solution)
+	assert.Equal(t, [][]rune{{'A', '.', '#'}, {'.', '.', '.'}, {'#', '.', '.'}}, puzzle.Fill)
+	expectedClues := []string{"1-Across AB", "1-Down AW", "2-Down BAA", "3-Across WAX", "4-Down XI", "5-Across AI"}
+	assert.Equal(t, expectedClues, puzzle.Clues)
+}
+
+func TestWrite(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B', '#'},
+		{'W', 'A', 'X'},
+		{'#', 'A', 'I'},
+	}
+	var output bytes.Buffer
+
+	err = Write(&output, g, solution, nil)
+
+	require.NoError(t, err)
+	puzzle, err := Decode(&output)
+	require.NoError(t, err)
+	assert.Equal(t, solution, puzzle.Solution)
+	assert.Equal(t, []string{"", "", "", "", "", ""}, puzzle.Clues)
+}
+
+func TestWrite_Fixture(t *testing.T) {
+	expected, err := os.ReadFile("testdata/sample.puz")
+	require.NoError(t, err)
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B', '#'},
+		{'W', 'A', 'X'},
+		{'#', 'A', 'I'},
+	}
+	puzzle := New(g, solution, nil)
+	puzzle.Title, puzzle.Author, puzzle.Copyright, puzzle.Notes = "Sample", "Crogo", "© Crogo", "Handcrafted"
+	puzzle.Clues = []string{"First two letters", "Oddly, a wonder", "Sheep sound", "Beeswax", "Greek letter",
+		"Artificial intelligence"}
+	var output bytes.Buffer
+
+	err = puzzle.Encode(&output)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, output.Bytes())
+}
@@ -0,0 +1,94 @@
+package puz
+
+import (
+	"crogo/pkg/grid"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Names of the supported extra sections.
+const (
+	// extensionRebusGrid is the name of the section locating rebus squares.
+	extensionRebusGrid = "GRBS"
+	// extensionRebusTable is the name of the section containing the rebus solutions.
+	extensionRebusTable = "RTBL"
+	// extensionMarkup is the name of the section containing the square markup.
+	extensionMarkup = "GEXT"
+)
+
+// Square markup flags of the GEXT section.
+const (
+	MarkupPreviouslyIncorrect byte = 0x10
+	MarkupIncorrect           byte = 0x20
+	MarkupGiven               byte = 0x40
+	MarkupCircled             byte = 0x80
+)
+
+// Extension returns the data of the extra section with the given name, if present.
+func (p *Puzzle) Extension(name string) ([]byte, bool) {
+	for _, extension := range p.Extensions {
+		if extension.Name == name {
+			return extension.Data, true
+		}
+	}
+	return nil, false
+}
+
+// Markup returns the markup flags of the square at the given position, 0 if none.
+func (p *Puzzle) Markup(pos grid.Pos) byte {
+	markup, ok := p.Extension(extensionMarkup)
+	index := pos.Row()*p.width() + pos.Column()
+	if !ok || index >= len(markup) {
+		return 0
+	}
+	return markup[index]
+}
+
+// Rebus returns the solutions of the rebus squares, i.e. the squares containing several letters, indexed by position.
+// The Solution of a rebus square only contains the first letter of its rebus solution.
+func (p *Puzzle) Rebus() (map[grid.Pos]string, error) {
+	rebusGrid, hasGrid := p.Extension(extensionRebusGrid)
+	rebusTable, hasTable := p.Extension(extensionRebusTable)
+	if !hasGrid || !hasTable {
+		return nil, nil
+	}
+	solutions, err := parseRebusTable(string(latin1Decode(rebusTable)))
+	if err != nil {
+		return nil, err
+	}
+	width := p.width()
+	if len(rebusGrid) != width*len(p.Solution) {
+		return nil, fmt.Errorf("invalid puz: rebus grid of %d squares for a %dx%d grid", len(rebusGrid), width,
+			len(p.Solution))
+	}
+	rebus := make(map[grid.Pos]string)
+	for index, value := range rebusGrid {
+		if value == 0 {
+			continue
+		}
+		solution, ok := solutions[int(value)-1]
+		if !ok {
+			return nil, fmt.Errorf("invalid puz: unknown rebus key %v", int(value)-1)
+		}
+		rebus[grid.NewPos(index%width, index/width)] = solution
+	}
+	return rebus, nil
+}
+
+// parseRebusTable parses the given rebus table, e.g. " 0:HEART; 1:DIAMOND;".
+func parseRebusTable(table string) (map[int]string, error) {
+	solutions := make(map[int]string)
+	for _, entry := range strings.Split(table, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		key, solution, found := strings.Cut(entry, ":")
+		keyNumber, err := strconv.Atoi(strings.TrimSpace(key))
+		if !found || err != nil {
+			return nil, fmt.Errorf("invalid puz: bad rebus table entry %q", entry)
+		}
+		solutions[keyNumber] = solution
+	}
+	return solutions, nil
+}
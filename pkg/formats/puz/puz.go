@@ -0,0 +1,320 @@
+// Package puz reads and writes crosswords in the Across Lite .puz binary format.
+//
+// The format is a fixed-size header followed by the solution and the player's fill, a table of NUL-terminated strings
+// (title, author, copyright, clues and notes) and optional extra sections, e.g. for rebus or circled squares. Most of
+// these parts are protected by checksums. See https://code.google.com/archive/p/puz/wikis/FileFormat.wiki for the
+// details.
+package puz
+
+import (
+	"bufio"
+	"bytes"
+	"crogo/pkg/grid"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	// headerSize is the size of the header in bytes.
+	headerSize = 0x34
+	// magic is the file magic, located at offset 0x02.
+	magic = "ACROSS&DOWN\x00"
+	// defaultVersion is the version written in new puzzles.
+	defaultVersion = "1.3"
+	// puzzleTypeNormal is the puzzle type of normal puzzles, as opposed to diagramless ones.
+	puzzleTypeNormal = 0x0001
+)
+
+// Cell values of the .puz solution and fill.
+const (
+	puzBlock = '.'
+	puzEmpty = '-'
+)
+
+// ErrScrambled is returned when the solution of the puzzle is scrambled, hence cannot be read.
+var ErrScrambled = errors.New("scrambled solution is not supported")
+
+// Puzzle is an Across Lite puzzle.
+type Puzzle struct {
+	// Version is the version of the format, e.g. "1.3".
+	Version string
+	// Solution is the solution of the puzzle, row by row. Blocks are grid.CellBlock, unknown letters - which are not
+	// allowed by Across Lite but may be produced by construction tools - are grid.CellEmpty.
+	Solution [][]rune
+	// Fill is the state of the player's grid, row by row. Blocks are grid.CellBlock, empty cells are grid.CellEmpty.
+	Fill       [][]rune
+	Title      string
+	Author     string
+	Copyright  string
+	Clues      []string
+	Notes      string
+	Extensions []Extension
+	// PuzzleType is the puzzle type bitmask.
+	PuzzleType uint16
+	// ScrambledTag is non-zero if the solution is scrambled.
+	ScrambledTag uint16
+	// ScrambledChecksum is the checksum of the real solution when it is scrambled.
+	ScrambledChecksum uint16
+}
+
+// Extension is an extra section of a puzzle, e.g. "GEXT" for square markup.
+type Extension struct {
+	// Name is the 4-character section name.
+	Name string
+	// Data is the content of the section.
+	Data []byte
+}
+
+// Decode decodes a puzzle from the given reader, verifying its checksums.
+func Decode(r io.Reader) (*Puzzle, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read puz: %w", err)
+	}
+	if len(content) < headerSize || string(content[0x02:0x0E]) != magic {
+		return nil, errors.New("invalid puz: not an Across Lite file")
+	}
+	header := content[:headerSize]
+	width, height := int(header[0x2C]), int(header[0x2D])
+	clueCount := int(binary.LittleEndian.Uint16(header[0x2E:]))
+	puzzle := &Puzzle{
+		Version:           string(bytes.TrimRight(header[0x18:0x1C], "\x00")),
+		PuzzleType:        binary.LittleEndian.Uint16(header[0x30:]),
+		ScrambledTag:      binary.LittleEndian.Uint16(header[0x32:]),
+		ScrambledChecksum: binary.LittleEndian.Uint16(header[0x1E:]),
+	}
+
+	body := content[headerSize:]
+	cellCount := width * height
+	if len(body) < 2*cellCount {
+		return nil, errors.New("invalid puz: truncated grids")
+	}
+	puzzle.Solution = decodeGrid(body[:cellCount], width, height)
+	puzzle.Fill = decodeGrid(body[cellCount:2*cellCount], width, height)
+	remaining := bufio.NewReader(bytes.NewReader(body[2*cellCount:]))
+	texts := make([]string, 0, clueCount+4)
+	for range clueCount + 4 {
+		text, err := readString(remaining)
+		if err != nil {
+			return nil, fmt.Errorf("invalid puz: truncated strings: %w", err)
+		}
+		texts = append(texts, text)
+	}
+	puzzle.Title, puzzle.Author, puzzle.Copyright = texts[0], texts[1], texts[2]
+	puzzle.Clues = texts[3 : 3+clueCount]
+	puzzle.Notes = texts[3+clueCount]
+	if puzzle.Extensions, err = readExtensions(remaining); err != nil {
+		return nil, err
+	}
+
+	if err = puzzle.verifyChecksums(content); err != nil {
+		return nil, err
+	}
+	return puzzle, nil
+}
+
+// decodeGrid converts the given .puz grid bytes to cells.
+func decodeGrid(data []byte, width, height int) [][]rune {
+	decoded := latin1Decode(data)
+	cells := make([][]rune, height)
+	for row := range height {
+		cells[row] = make([]rune, width)
+		for column := range width {
+			switch value := decoded[row*width+column]; value {
+			case puzBlock:
+				cells[row][column] = grid.CellBlock
+			case puzEmpty:
+				cells[row][column] = grid.CellEmpty
+			default:
+				cells[row][column] = value
+			}
+		}
+	}
+	return cells
+}
+
+// readString reads a NUL-terminated ISO-8859-1 string.
+func readString(r *bufio.Reader) (string, error) {
+	data, err := r.ReadBytes(0)
+	if err != nil {
+		return "", fmt.Errorf("cannot read string: %w", err)
+	}
+	return string(latin1Decode(data[:len(data)-1])), nil
+}
+
+// readExtensions reads the extra sections until the end of the given reader.
+func readExtensions(r *bufio.Reader) ([]Extension, error) {
+	var extensions []Extension
+	for {
+		sectionHeader := make([]byte, 8)
+		if _, err := io.ReadFull(r, sectionHeader); err == io.EOF {
+			return extensions, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid puz: truncated extension header: %w", err)
+		}
+		name := string(sectionHeader[:4])
+		length := int(binary.LittleEndian.Uint16(sectionHeader[4:]))
+		expectedChecksum := binary.LittleEndian.Uint16(sectionHeader[6:])
+		data := make([]byte, length+1) // data is NUL-terminated
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, fmt.Errorf("invalid puz: truncated extension %s: %w", name, err)
+		}
+		data = data[:length]
+		if actualChecksum := checksum(data, 0); actualChecksum != expectedChecksum {
+			return nil, fmt.Errorf("invalid puz: bad checksum for extension %s", name)
+		}
+		extensions = append(extensions, Extension{name, data})
+	}
+}
+
+// verifyChecksums verifies the checksums of the given content, from which this puzzle has been decoded.
+func (p *Puzzle) verifyChecksums(content []byte) error {
+	expectedHeader := p.header(content[headerSize:])
+	for _, offset := range []int{0x00, 0x0E, 0x10} {
+		length := 2
+		if offset == 0x10 {
+			length = 8 // masked checksums
+		}
+		if !bytes.Equal(content[offset:offset+length], expectedHeader[offset:offset+length]) {
+			return fmt.Errorf("invalid puz: bad checksum at offset %#x", offset)
+		}
+	}
+	return nil
+}
+
+// Encode encodes this puzzle to the given writer, computing its checksums.
+func (p *Puzzle) Encode(w io.Writer) error {
+	var body bytes.Buffer
+	body.Write(encodeGrid(p.Solution))
+	body.Write(encodeGrid(p.Fill))
+	texts := append([]string{p.Title, p.Author, p.Copyright}, p.Clues...)
+	for _, text := range append(texts, p.Notes) {
+		body.Write(latin1Encode(text))
+		body.WriteByte(0)
+	}
+	for _, extension := range p.Extensions {
+		sectionHeader := make([]byte, 8)
+		copy(sectionHeader, extension.Name)
+		binary.LittleEndian.PutUint16(sectionHeader[4:], uint16(len(extension.Data)))
+		binary.LittleEndian.PutUint16(sectionHeader[6:], checksum(extension.Data, 0))
+		body.Write(sectionHeader)
+		body.Write(extension.Data)
+		body.WriteByte(0)
+	}
+	if _, err := w.Write(append(p.header(body.Bytes()), body.Bytes()...)); err != nil {
+		return fmt.Errorf("cannot write puz: %w", err)
+	}
+	return nil
+}
+
+// encodeGrid converts the given cells to .puz grid bytes.
+func encodeGrid(cells [][]rune) []byte {
+	var data []rune
+	for _, row := range cells {
+		for _, cell := range row {
+			switch cell {
+			case grid.CellBlock:
+				data = append(data, puzBlock)
+			case grid.CellEmpty:
+				data = append(data, puzEmpty)
+			default:
+				data = append(data, cell)
+			}
+		}
+	}
+	return latin1Encode(string(data))
+}
+
+// header returns the header of this puzzle, including the checksums of the given body.
+func (p *Puzzle) header(body []byte) []byte {
+	header := make([]byte, headerSize)
+	copy(header[0x02:], magic)
+	copy(header[0x18:0x1C], p.Version)
+	binary.LittleEndian.PutUint16(header[0x1E:], p.ScrambledChecksum)
+	header[0x2C] = byte(p.width())
+	header[0x2D] = byte(len(p.Solution))
+	binary.LittleEndian.PutUint16(header[0x2E:], uint16(len(p.Clues)))
+	binary.LittleEndian.PutUint16(header[0x30:], p.PuzzleType)
+	binary.LittleEndian.PutUint16(header[0x32:], p.ScrambledTag)
+
+	cellCount := p.width() * len(p.Solution)
+	solution, fill := body[:cellCount], body[cellCount:2*cellCount]
+	cibChecksum := checksum(header[0x2C:0x34], 0)
+	solutionChecksum := checksum(solution, 0)
+	fillChecksum := checksum(fill, 0)
+	textsChecksum := p.textsChecksum(0)
+	binary.LittleEndian.PutUint16(header[0x0E:], cibChecksum)
+	globalChecksum := checksum(solution, cibChecksum)
+	globalChecksum = checksum(fill, globalChecksum)
+	binary.LittleEndian.PutUint16(header[0x00:], p.textsChecksum(globalChecksum))
+	checksums := []uint16{cibChecksum, solutionChecksum, fillChecksum, textsChecksum}
+	for i, mask := range []byte("ICHEATED") {
+		partialChecksum := checksums[i%4]
+		if i < 4 {
+			header[0x10+i] = mask ^ byte(partialChecksum)
+		} else {
+			header[0x10+i] = mask ^ byte(partialChecksum>>8)
+		}
+	}
+	return header
+}
+
+// width returns the width of this puzzle.
+func (p *Puzzle) width() int {
+	if len(p.Solution) == 0 {
+		return 0
+	}
+	return len(p.Solution[0])
+}
+
+// textsChecksum computes the checksum of the texts of this puzzle, starting from the given checksum.
+func (p *Puzzle) textsChecksum(sum uint16) uint16 {
+	for _, text := range []string{p.Title, p.Author, p.Copyright} {
+		if text != "" {
+			sum = checksum(append(latin1Encode(text), 0), sum)
+		}
+	}
+	for _, clue := range p.Clues {
+		sum = checksum(latin1Encode(clue), sum)
+	}
+	if p.Notes != "" && p.Version >= "1.3" {
+		sum = checksum(append(latin1Encode(p.Notes), 0), sum)
+	}
+	return sum
+}
+
+// checksum computes the .puz checksum of the given data, starting from the given checksum.
+func checksum(data []byte, sum uint16) uint16 {
+	for _, b := range data {
+		if sum&1 != 0 {
+			sum = sum>>1 + 0x8000
+		} else {
+			sum = sum >> 1
+		}
+		sum += uint16(b)
+	}
+	return sum
+}
+
+// latin1Decode decodes the given ISO-8859-1 data.
+func latin1Decode(data []byte) []rune {
+	decoded, _ := charmap.ISO8859_1.NewDecoder().Bytes(data)
+	return []rune(string(decoded))
+}
+
+// latin1Encode encodes the given text in ISO-8859-1, replacing unsupported characters with '?'.
+func latin1Encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		b, ok := charmap.ISO8859_1.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		encoded = append(encoded, b)
+	}
+	return encoded
+}
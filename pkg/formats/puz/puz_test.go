@@ -0,0 +1,118 @@
+package puz
+
+import (
+	"bytes"
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	content, err := os.ReadFile("testdata/sample.puz")
+	require.NoError(t, err)
+
+	puzzle, err := Decode(bytes.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Equal(t, "1.3", puzzle.Version)
+	assert.Equal(t, [][]rune{{'A', 'B', '#'}, {'W', 'A', 'X'}, {'#', 'A', 'I'}}, puzzle.Solution)
+	assert.Equal(t, [][]rune{{'A', '.', '#'}, {'.', '.', '.'}, {'#', '.', '.'}}, puzzle.Fill)
+	assert.Equal(t, "Sample", puzzle.Title)
+	assert.Equal(t, "Crogo", puzzle.Author)
+	assert.Equal(t, "© Crogo", puzzle.Copyright)
+	expectedClues := []string{"First two letters", "Oddly, a wonder", "Sheep sound", "Beeswax", "Greek letter",
+		"Artificial intelligence"}
+	assert.Equal(t, expectedClues, puzzle.Clues)
+	assert.Equal(t, "Handcrafted", puzzle.Notes)
+	assert.Empty(t, puzzle.Extensions)
+}
+
+func TestDecode_BadChecksum(t *testing.T) {
+	content, err := os.ReadFile("testdata/sample.puz")
+	require.NoError(t, err)
+	content[headerSize] = 'Z' // alter the solution
+
+	_, err = Decode(bytes.NewReader(content))
+
+	assert.EqualError(t, err, "invalid puz: bad checksum at offset 0x0")
+}
+
+func TestDecode_NotPuz(t *testing.T) {
+	_, err := Decode(bytes.NewReader([]byte("{}")))
+
+	assert.EqualError(t, err, "invalid puz: not an Across Lite file")
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	for _, fixture := range []string{"testdata/sample.puz", "testdata/rebus.puz"} {
+		t.Run(fixture, func(t *testing.T) {
+			content, err := os.ReadFile(fixture)
+			require.NoError(t, err)
+			puzzle, err := Decode(bytes.NewReader(content))
+			require.NoError(t, err)
+			var output bytes.Buffer
+
+			err = puzzle.Encode(&output)
+
+			require.NoError(t, err)
+			assert.Equal(t, content, output.Bytes())
+		})
+	}
+}
+
+func TestRebus(t *testing.T) {
+	file, err := os.Open("testdata/rebus.puz")
+	require.NoError(t, err)
+	defer file.Close()
+	puzzle, err := Decode(file)
+	require.NoError(t, err)
+
+	rebus, err := puzzle.Rebus()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[grid.Pos]string{grid.NewPos(0, 0): "HEART"}, rebus)
+	assert.Equal(t, MarkupCircled, puzzle.Markup(grid.NewPos(1, 1)))
+	assert.Zero(t, puzzle.Markup(grid.NewPos(0, 0)))
+}
+
+func TestRebus_None(t *testing.T) {
+	file, err := os.Open("testdata/sample.puz")
+	require.NoError(t, err)
+	defer file.Close()
+	puzzle, err := Decode(file)
+	require.NoError(t, err)
+
+	rebus, err := puzzle.Rebus()
+
+	require.NoError(t, err)
+	assert.Empty(t, rebus)
+	assert.Zero(t, puzzle.Markup(grid.NewPos(0, 0)))
+}
+
+func TestRebus_BadGridLength(t *testing.T) {
+	puzzle := &Puzzle{Solution: [][]rune{{'A', 'B'}}, Extensions: []Extension{
+		{Name: extensionRebusGrid, Data: []byte{1, 0, 0}},
+		{Name: extensionRebusTable, Data: []byte(" 0:HEART;")},
+	}}
+
+	_, err := puzzle.Rebus()
+
+	assert.EqualError(t, err, "invalid puz: rebus grid of 3 squares for a 2x1 grid")
+}
+
+func TestRebus_EmptySolution(t *testing.T) {
+	puzzle := &Puzzle{Extensions: []Extension{
+		{Name: extensionRebusGrid, Data: []byte{1}},
+		{Name: extensionRebusTable, Data: []byte(" 0:HEART;")},
+	}}
+
+	_, err := puzzle.Rebus()
+
+	assert.EqualError(t, err, "invalid puz: rebus grid of 1 squares for a 0x0 grid")
+}
+
+func TestLatin1Encode(t *testing.T) {
+	assert.Equal(t, []byte("caf\xe9 ?"), latin1Encode("café 🐊"))
+}
@@ -0,0 +1,28 @@
+package grid
+
+// Entry is the word written in a numbered slot of a filled grid.
+type Entry struct {
+	NumberedSlot
+	word string
+}
+
+// Word returns the word of this entry.
+func (e *Entry) Word() string {
+	return e.word
+}
+
+// Entries returns the entries of the given filled cells, which must have the dimensions of this grid. Entries are
+// ordered like NumberedSlots.
+func (g *Grid) Entries(filledCells [][]rune) []Entry {
+	numberedSlots := g.NumberedSlots()
+	entries := make([]Entry, len(numberedSlots))
+	for i, numberedSlot := range numberedSlots {
+		positions := numberedSlot.Positions()
+		word := make([]rune, len(positions))
+		for j, pos := range positions {
+			word[j] = filledCells[pos.Row()][pos.Column()]
+		}
+		entries[i] = Entry{numberedSlot, string(word)}
+	}
+	return entries
+}
@@ -0,0 +1,35 @@
+package grid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEntries(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+	filledCells := [][]rune{
+		{'A', 'B', '#'},
+		{'C', 'D', 'E'},
+		{'#', 'F', 'G'},
+	}
+
+	entries := grid.Entries(filledCells)
+
+	actual := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		actual[entry.String()] = entry.Word()
+	}
+	expected := map[string]string{
+		"1-Across": "AB",
+		"3-Across": "CDE",
+		"5-Across": "FG",
+		"1-Down":   "AC",
+		"2-Down":   "BDF",
+		"4-Down":   "EG",
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -1,51 +1,33 @@
+// Package grid defines the crossword grid and its slots, i.e. the places where words are written.
 package grid
 
 import (
-	"crogo/internal/alphabet"
-	"fmt"
 	"slices"
 )
 
+// Special cell values.
 const (
+	// CellBlock is the value of a shaded cell, which cannot contain a letter.
 	CellBlock = '#'
+	// CellEmpty is the value of a cell to fill.
 	CellEmpty = '.'
 )
 
+// Grid is a crossword grid: a rectangle of cells, each containing either a letter, a block or nothing yet.
 type Grid struct {
 	cells [][]rune
 }
 
 // NewGrid attempts to create a new Grid from given cells. Function returns the grid if given input is valid, otherwise
-// it returns an error containing details about the validation failure.
+// it returns an error joining all the validation errors, see Validate.
 func NewGrid(cells [][]rune) (*Grid, error) {
-	err := validate(cells)
+	err := Validate(cells).Err()
 	if err != nil {
 		return &Grid{}, err
 	}
 	return &Grid{cells}, nil
 }
 
-// validate validates the given cells. Function returns an error iff validation fails.
-func validate(cells [][]rune) error {
-	if len(cells) == 0 {
-		// Trivial case, empty Grid
-		return nil
-	}
-	firstRowColumnCount := len(cells[0])
-	for rowIndex, row := range cells {
-		columnCount := len(row)
-		if firstRowColumnCount != columnCount {
-			return fmt.Errorf("inconsistent number of columns: Row #%v has %v columns but row #0 has %v", rowIndex, columnCount, firstRowColumnCount)
-		}
-		for columnIndex, value := range row {
-			if value != CellEmpty && value != CellBlock && !alphabet.Contains(value) {
-				return fmt.Errorf("invalid value at row #%v, column #%v: %v", rowIndex, columnIndex, string(value))
-			}
-		}
-	}
-	return nil
-}
-
 // LetterAt returns the letter at given position.
 //
 // Special character '#' is returned if the cell contains a block.
@@ -54,6 +36,15 @@ func (g *Grid) LetterAt(row, column int) rune {
 	return g.cells[row][column]
 }
 
+// Cells returns a copy of the cells of this grid.
+func (g *Grid) Cells() [][]rune {
+	cells := make([][]rune, len(g.cells))
+	for i, row := range g.cells {
+		cells[i] = slices.Clone(row)
+	}
+	return cells
+}
+
 // Slots returns the slots of this grid.
 func (g *Grid) Slots() []Slot {
 	return slices.Concat(g.acrossSlots(), g.downSlots())
@@ -0,0 +1,94 @@
+package grid
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NumberedSlot is a Slot associated to its clue number.
+type NumberedSlot struct {
+	Slot
+	number int
+}
+
+// Number returns the clue number of this slot.
+func (n NumberedSlot) Number() int {
+	return n.number
+}
+
+// String returns the usual designation of this slot, e.g. "1-Across".
+func (n NumberedSlot) String() string {
+	return fmt.Sprintf("%v-%v", n.number, n.Direction())
+}
+
+// ClueNumbers returns the clue numbers of the grid, indexed by the position of the cells they are written in.
+//
+// Numbering follows the standard crossword convention: Cells starting at least one slot are numbered consecutively
+// from 1, in reading order.
+func (g *Grid) ClueNumbers() map[Pos]int {
+	startingCells := make(map[Pos]bool)
+	for _, slot := range g.Slots() {
+		startingCells[slot.Start()] = true
+	}
+	numbers := make(map[Pos]int, len(startingCells))
+	number := 1
+	for row := 0; row < g.RowCount(); row++ {
+		for column := 0; column < g.ColumnCount(); column++ {
+			pos := NewPos(column, row)
+			if startingCells[pos] {
+				numbers[pos] = number
+				number++
+			}
+		}
+	}
+	return numbers
+}
+
+// NumberedSlots returns the slots of this grid with their clue number. Across slots come first, then down slots, each
+// group sorted by clue number.
+func (g *Grid) NumberedSlots() []NumberedSlot {
+	numbers := g.ClueNumbers()
+	slots := g.Slots()
+	numberedSlots := make([]NumberedSlot, len(slots))
+	for i, slot := range slots {
+		numberedSlots[i] = NumberedSlot{slot, numbers[slot.Start()]}
+	}
+	slices.SortStableFunc(numberedSlots, func(a, b NumberedSlot) int {
+		if a.Direction() != b.Direction() {
+			return int(a.Direction() - b.Direction())
+		}
+		return a.number - b.number
+	})
+	return numberedSlots
+}
+
+// NumberedSlotNamed returns the numbered slot of this grid with the given designation, i.e. its clue number followed by
+// its direction or the first letters of its direction, case-insensitive, e.g. "1-Across", "1a", "1A" or "2d".
+func (g *Grid) NumberedSlotNamed(name string) (NumberedSlot, error) {
+	numberEnd := strings.IndexFunc(name, func(r rune) bool { return r < '0' || r > '9' })
+	if numberEnd > 0 {
+		number := name[:numberEnd]
+		direction := strings.ToLower(strings.TrimPrefix(name[numberEnd:], "-"))
+		for _, slot := range g.NumberedSlots() {
+			slotDirection := strings.ToLower(slot.Direction().String())
+			if strconv.Itoa(slot.number) == number && direction != "" && strings.HasPrefix(slotDirection, direction) {
+				return slot, nil
+			}
+		}
+	}
+	return NumberedSlot{}, fmt.Errorf("unknown entry: %s", name)
+}
+
+// SlotsAt returns the slots containing the cell at the given position, i.e. at most one across slot and one down
+// slot.
+func (g *Grid) SlotsAt(pos Pos) []Slot {
+	var slots []Slot
+	for _, slot := range g.Slots() {
+		if slot.Contains(pos) {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
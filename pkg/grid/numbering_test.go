@@ -0,0 +1,75 @@
+package grid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestClueNumbers(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+
+	expectedNumbers := map[Pos]int{
+		NewPos(0, 0): 1,
+		NewPos(1, 0): 2,
+		NewPos(0, 1): 3,
+		NewPos(2, 1): 4,
+		NewPos(1, 2): 5,
+	}
+	assert.Equal(t, expectedNumbers, grid.ClueNumbers())
+}
+
+func TestNumberedSlots(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+
+	numberedSlots := grid.NumberedSlots()
+
+	var actualDesignations []string
+	for _, numberedSlot := range numberedSlots {
+		actualDesignations = append(actualDesignations, numberedSlot.String())
+	}
+	expectedDesignations := []string{"1-Across", "3-Across", "5-Across", "1-Down", "2-Down", "4-Down"}
+	assert.Equal(t, expectedDesignations, actualDesignations)
+	assert.Equal(t, NewAcrossSlot(0, 3, 1), numberedSlots[1].Slot)
+	assert.Equal(t, NewDownSlot(1, 3, 2), numberedSlots[5].Slot)
+}
+
+func TestSlotsAt(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+
+	assert.Equal(t, []Slot{NewAcrossSlot(0, 3, 1), NewDownSlot(0, 3, 1)}, grid.SlotsAt(NewPos(1, 1)))
+	assert.Nil(t, grid.SlotsAt(NewPos(2, 0)))
+}
+
+func TestNumberedSlotNamed(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+
+	for _, name := range []string{"3-Across", "3a", "3A", "3-ac"} {
+		numberedSlot, err := grid.NumberedSlotNamed(name)
+		assert.NoError(t, err)
+		assert.Equal(t, "3-Across", numberedSlot.String())
+	}
+	numberedSlot, err := grid.NumberedSlotNamed("2d")
+	assert.NoError(t, err)
+	assert.Equal(t, "2-Down", numberedSlot.String())
+
+	for _, name := range []string{"3", "3d", "a", "7a", "3-Acrossx", ""} {
+		_, err = grid.NumberedSlotNamed(name)
+		assert.EqualError(t, err, "unknown entry: "+name)
+	}
+}
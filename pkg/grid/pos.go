@@ -0,0 +1,28 @@
+package grid
+
+import "fmt"
+
+// Pos is the position of a cell in a grid.
+type Pos struct {
+	column int
+	row    int
+}
+
+// NewPos creates a new Pos.
+func NewPos(column, row int) Pos {
+	return Pos{column, row}
+}
+
+// Column returns the column index of this position, starting at 0.
+func (p *Pos) Column() int {
+	return p.column
+}
+
+// Row returns the row index of this position, starting at 0.
+func (p *Pos) Row() int {
+	return p.row
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("(row #%v, column #%v)", p.row, p.column)
+}
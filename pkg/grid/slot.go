@@ -0,0 +1,108 @@
+package grid
+
+import "unicode/utf8"
+
+// SlotMinLength is the minimal length of a slot: Shorter sequences of cells are not considered as slots.
+const SlotMinLength = 2
+
+// Direction is the direction of a Slot.
+type Direction int
+
+const (
+	// Across is the direction of the slots read from left to right.
+	Across Direction = iota
+	// Down is the direction of the slots read from top to bottom.
+	Down
+)
+
+func (d Direction) String() string {
+	if d == Down {
+		return "Down"
+	}
+	return "Across"
+}
+
+// Slot is a sequence of at least SlotMinLength cells which must contain a word.
+type Slot struct {
+	start  int
+	end    int
+	offset int
+	isDown bool
+}
+
+func newSlot(start, end, offset int, isDown bool) Slot {
+	return Slot{
+		start,
+		end,
+		offset,
+		isDown,
+	}
+}
+
+// NewAcrossSlot creates a new across Slot.
+func NewAcrossSlot(startColumn, endColumn, row int) Slot {
+	return newSlot(startColumn, endColumn, row, false)
+}
+
+// NewDownSlot creates a new down Slot.
+func NewDownSlot(startRow, endRow, column int) Slot {
+	return newSlot(startRow, endRow, column, true)
+}
+
+// Direction returns the direction of this slot.
+func (s *Slot) Direction() Direction {
+	if s.isDown {
+		return Down
+	}
+	return Across
+}
+
+// Start returns the position of the first cell of this slot.
+func (s *Slot) Start() Pos {
+	if s.isDown {
+		return NewPos(s.offset, s.start)
+	}
+	return NewPos(s.start, s.offset)
+}
+
+// Positions returns the positions of the cells of this slot.
+func (s *Slot) Positions() []Pos {
+	var positions []Pos
+	for i := s.start; i < s.end; i++ {
+		if s.isDown {
+			positions = append(positions, NewPos(s.offset, i))
+		} else {
+			positions = append(positions, NewPos(i, s.offset))
+		}
+	}
+	return positions
+}
+
+// Contains returns true iff the given position is one of the cells of this slot.
+func (s *Slot) Contains(pos Pos) bool {
+	if s.isDown {
+		return pos.Column() == s.offset && pos.Row() >= s.start && pos.Row() < s.end
+	}
+	return pos.Row() == s.offset && pos.Column() >= s.start && pos.Column() < s.end
+}
+
+// Length returns the length of this slot.
+func (s *Slot) Length() int {
+	return s.end - s.start
+}
+
+// Matches returns true iff the given word fits in a slot whose cells have the given values, in which CellEmpty matches
+// any letter.
+func Matches(word string, pattern []rune) bool {
+	if utf8.RuneCountInString(word) != len(pattern) {
+		return false
+	}
+	i := 0
+	for _, letter := range word {
+		if pattern[i] != CellEmpty && pattern[i] != letter {
+			return false
+		}
+		i++
+	}
+	return true
+}
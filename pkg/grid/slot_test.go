@@ -0,0 +1,49 @@
+package grid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPositions_Across(t *testing.T) {
+	slot := NewAcrossSlot(1, 4, 1)
+	positions := slot.Positions()
+	expectedPositions := []Pos{NewPos(1, 1), NewPos(2, 1), NewPos(3, 1)}
+	assert.Equal(t, expectedPositions, positions)
+}
+
+func TestPositions_Down(t *testing.T) {
+	slot := NewDownSlot(1, 4, 1)
+	positions := slot.Positions()
+	expectedPositions := []Pos{NewPos(1, 1), NewPos(1, 2), NewPos(1, 3)}
+	assert.Equal(t, expectedPositions, positions)
+}
+
+func TestStart(t *testing.T) {
+	acrossSlot := NewAcrossSlot(1, 4, 2)
+	downSlot := NewDownSlot(1, 4, 2)
+	assert.Equal(t, NewPos(1, 2), acrossSlot.Start())
+	assert.Equal(t, NewPos(2, 1), downSlot.Start())
+}
+
+func TestDirection(t *testing.T) {
+	acrossSlot := NewAcrossSlot(1, 4, 2)
+	downSlot := NewDownSlot(1, 4, 2)
+	assert.Equal(t, Across, acrossSlot.Direction())
+	assert.Equal(t, Down, downSlot.Direction())
+}
+
+func TestContains(t *testing.T) {
+	slot := NewDownSlot(1, 4, 2)
+	assert.True(t, slot.Contains(NewPos(2, 1)))
+	assert.True(t, slot.Contains(NewPos(2, 3)))
+	assert.False(t, slot.Contains(NewPos(2, 4)))
+	assert.False(t, slot.Contains(NewPos(1, 2)))
+}
+
+func TestMatches(t *testing.T) {
+	assert.True(t, Matches("ABC", []rune("A.C")))
+	assert.True(t, Matches("ÉTÉ", []rune("É..")))
+	assert.False(t, Matches("ABC", []rune("B..")))
+	assert.False(t, Matches("ABC", []rune("....")))
+}
@@ -0,0 +1,131 @@
+package grid
+
+// Symmetry is a geometric transformation of a grid: a reflection or a rotation.
+type Symmetry int
+
+const (
+	// Transpose reflects the grid across its main diagonal, from top-left to bottom-right. Across slots become down
+	// slots, read in the same order.
+	Transpose Symmetry = iota
+	// AntiTranspose reflects the grid across its anti-diagonal, from top-right to bottom-left.
+	AntiTranspose
+	// HorizontalMirror reflects the grid across its vertical axis, i.e. swaps its left and right sides.
+	HorizontalMirror
+	// VerticalMirror reflects the grid across its horizontal axis, i.e. swaps its top and bottom sides.
+	VerticalMirror
+	// Rotation90 rotates the grid by 90° clockwise.
+	Rotation90
+	// Rotation180 rotates the grid by 180°.
+	Rotation180
+	// Rotation270 rotates the grid by 270° clockwise.
+	Rotation270
+)
+
+// symmetries are all the symmetries, in declaration order.
+var symmetries = []Symmetry{
+	Transpose, AntiTranspose, HorizontalMirror, VerticalMirror, Rotation90, Rotation180, Rotation270,
+}
+
+func (s Symmetry) String() string {
+	switch s {
+	case Transpose:
+		return "Transpose"
+	case AntiTranspose:
+		return "AntiTranspose"
+	case HorizontalMirror:
+		return "HorizontalMirror"
+	case VerticalMirror:
+		return "VerticalMirror"
+	case Rotation90:
+		return "Rotation90"
+	case Rotation180:
+		return "Rotation180"
+	default:
+		return "Rotation270"
+	}
+}
+
+// isSquareOnly returns true iff this symmetry only applies to square grids.
+func (s Symmetry) isSquareOnly() bool {
+	return s == Transpose || s == AntiTranspose || s == Rotation90 || s == Rotation270
+}
+
+// Apply returns the image of the given position by this symmetry, in a grid of the given dimensions. Symmetries which
+// swap rows and columns are only defined for square grids.
+func (s Symmetry) Apply(pos Pos, rowCount, columnCount int) Pos {
+	lastRow, lastColumn := rowCount-1, columnCount-1
+	switch s {
+	case Transpose:
+		return NewPos(pos.row, pos.column)
+	case AntiTranspose:
+		return NewPos(lastRow-pos.row, lastColumn-pos.column)
+	case HorizontalMirror:
+		return NewPos(lastColumn-pos.column, pos.row)
+	case VerticalMirror:
+		return NewPos(pos.column, lastRow-pos.row)
+	case Rotation90:
+		return NewPos(lastRow-pos.row, pos.column)
+	case Rotation180:
+		return NewPos(lastColumn-pos.column, lastRow-pos.row)
+	default:
+		return NewPos(pos.row, lastColumn-pos.column)
+	}
+}
+
+// Symmetries returns the symmetries of this grid, i.e. the transformations mapping each cell to a cell with the same
+// value: Blocks to blocks, empty cells to empty cells and letters to the same letters.
+func (g *Grid) Symmetries() []Symmetry {
+	var gridSymmetries []Symmetry
+	for _, symmetry := range symmetries {
+		if symmetry.isSquareOnly() && g.RowCount() != g.ColumnCount() {
+			continue
+		}
+		if g.isInvariantBy(symmetry) {
+			gridSymmetries = append(gridSymmetries, symmetry)
+		}
+	}
+	return gridSymmetries
+}
+
+// isInvariantBy returns true iff the given symmetry maps each cell of this grid to a cell with the same value.
+func (g *Grid) isInvariantBy(symmetry Symmetry) bool {
+	for row, rowCells := range g.cells {
+		for column, cell := range rowCells {
+			image := symmetry.Apply(NewPos(column, row), g.RowCount(), g.ColumnCount())
+			if g.cells[image.row][image.column] != cell {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// PreservesWords returns true iff the given symmetry of this grid maps each slot to a slot read in the same order, so
+// that it maps the fills of this grid to fills with the same words.
+//
+// Reflections and rotations other than Transpose reverse the reading order of some slots: They only preserve words if
+// the grid has no such slot.
+func (g *Grid) PreservesWords(symmetry Symmetry) bool {
+	slots := make(map[Slot]bool)
+	for _, slot := range g.Slots() {
+		slots[slot] = true
+	}
+	for slot := range slots {
+		positions := slot.Positions()
+		first := symmetry.Apply(positions[0], g.RowCount(), g.ColumnCount())
+		second := symmetry.Apply(positions[1], g.RowCount(), g.ColumnCount())
+		var image Slot
+		switch {
+		case second.row == first.row && second.column == first.column+1:
+			image = NewAcrossSlot(first.column, first.column+slot.Length(), first.row)
+		case second.column == first.column && second.row == first.row+1:
+			image = NewDownSlot(first.row, first.row+slot.Length(), first.column)
+		default:
+			return false
+		}
+		if !slots[image] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package grid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSymmetry_Apply(t *testing.T) {
+	pos := NewPos(1, 0)
+
+	assert.Equal(t, NewPos(0, 1), Transpose.Apply(pos, 3, 3))
+	assert.Equal(t, NewPos(2, 1), AntiTranspose.Apply(pos, 3, 3))
+	assert.Equal(t, NewPos(1, 0), HorizontalMirror.Apply(pos, 3, 3))
+	assert.Equal(t, NewPos(1, 2), VerticalMirror.Apply(pos, 3, 3))
+	assert.Equal(t, NewPos(2, 1), Rotation90.Apply(pos, 3, 3))
+	assert.Equal(t, NewPos(1, 2), Rotation180.Apply(pos, 3, 3))
+	assert.Equal(t, NewPos(0, 1), Rotation270.Apply(pos, 3, 3))
+}
+
+func TestSymmetries_Empty(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	})
+
+	expectedSymmetries := []Symmetry{
+		Transpose, AntiTranspose, HorizontalMirror, VerticalMirror, Rotation90, Rotation180, Rotation270,
+	}
+	assert.Equal(t, expectedSymmetries, grid.Symmetries())
+}
+
+func TestSymmetries_Blocks(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+
+	assert.Equal(t, []Symmetry{Transpose, AntiTranspose, Rotation180}, grid.Symmetries())
+}
+
+func TestSymmetries_Letters(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'A', '.', '.'},
+		{'.', '.', '.'},
+		{'.', '.', 'B'},
+	})
+
+	assert.Equal(t, []Symmetry{Transpose}, grid.Symmetries())
+}
+
+func TestSymmetries_Rectangle(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '.'},
+		{'.', '.', '.'},
+	})
+
+	assert.Equal(t, []Symmetry{HorizontalMirror, VerticalMirror, Rotation180}, grid.Symmetries())
+}
+
+func TestPreservesWords(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '#'},
+		{'.', '.', '.'},
+		{'#', '.', '.'},
+	})
+
+	assert.True(t, grid.PreservesWords(Transpose))
+	assert.False(t, grid.PreservesWords(Rotation180))
+}
+
+func TestPreservesWords_AcrossOnly(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '.', '.'},
+		{'#', '#', '#'},
+		{'.', '.', '.'},
+	})
+
+	assert.True(t, grid.PreservesWords(VerticalMirror))
+	assert.False(t, grid.PreservesWords(HorizontalMirror))
+}
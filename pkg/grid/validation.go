@@ -0,0 +1,307 @@
+package grid
+
+import (
+	"crogo/internal/alphabet"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Severity is the severity of a validation Issue.
+type Severity int
+
+const (
+	// SeverityError indicates that the grid cannot be solved.
+	SeverityError Severity = iota
+	// SeverityWarning indicates that the grid can be solved but is probably not what was intended.
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Issue is a problem found while validating a grid.
+//
+// Issues are errors, whose concrete types carry the details of the problem, e.g. UnsupportedCharacterError.
+type Issue interface {
+	error
+	// Kind returns a short, stable identifier of the kind of issue, suitable for machine processing.
+	Kind() string
+	// Severity returns the severity of the issue.
+	Severity() Severity
+	// Pos returns the position of the cell where the issue is located.
+	Pos() Pos
+}
+
+// InconsistentRowLengthError indicates that a row does not have the same number of columns as the first row.
+type InconsistentRowLengthError struct {
+	Row                 int
+	ColumnCount         int
+	ExpectedColumnCount int
+}
+
+func (e *InconsistentRowLengthError) Error() string {
+	return fmt.Sprintf("inconsistent number of columns: Row #%v has %v columns but row #0 has %v", e.Row,
+		e.ColumnCount, e.ExpectedColumnCount)
+}
+
+func (e *InconsistentRowLengthError) Kind() string       { return "inconsistent-row-length" }
+func (e *InconsistentRowLengthError) Severity() Severity { return SeverityError }
+func (e *InconsistentRowLengthError) Pos() Pos           { return NewPos(0, e.Row) }
+
+// UnsupportedCharacterError indicates that a cell contains a value which is neither a letter of the alphabet, nor
+// CellEmpty nor CellBlock.
+type UnsupportedCharacterError struct {
+	Row    int
+	Column int
+	Value  rune
+}
+
+func (e *UnsupportedCharacterError) Error() string {
+	return fmt.Sprintf("invalid value at row #%v, column #%v: %v", e.Row, e.Column, string(e.Value))
+}
+
+func (e *UnsupportedCharacterError) Kind() string       { return "unsupported-character" }
+func (e *UnsupportedCharacterError) Severity() Severity { return SeverityError }
+func (e *UnsupportedCharacterError) Pos() Pos           { return NewPos(e.Column, e.Row) }
+
+// UncheckedCellError indicates that a cell is not part of any slot, i.e. it would form a one-letter word both across
+// and down. The solver fills such a cell with an arbitrary letter.
+type UncheckedCellError struct {
+	Row    int
+	Column int
+}
+
+func (e *UncheckedCellError) Error() string {
+	return fmt.Sprintf("unchecked cell at row #%v, column #%v: cell is not part of any slot", e.Row, e.Column)
+}
+
+func (e *UncheckedCellError) Kind() string       { return "unchecked-cell" }
+func (e *UncheckedCellError) Severity() Severity { return SeverityWarning }
+func (e *UncheckedCellError) Pos() Pos           { return NewPos(e.Column, e.Row) }
+
+// SlotTooLongError indicates that a slot is longer than the longest word available, hence cannot be filled.
+type SlotTooLongError struct {
+	Row           int
+	Column        int
+	Length        int
+	MaxWordLength int
+}
+
+func (e *SlotTooLongError) Error() string {
+	return fmt.Sprintf("slot too long at row #%v, column #%v: slot has %v cells but longest word has %v letters",
+		e.Row, e.Column, e.Length, e.MaxWordLength)
+}
+
+func (e *SlotTooLongError) Kind() string       { return "slot-too-long" }
+func (e *SlotTooLongError) Severity() Severity { return SeverityError }
+func (e *SlotTooLongError) Pos() Pos           { return NewPos(e.Column, e.Row) }
+
+// UnreachableRegionError indicates that a group of cells is separated from the biggest region of the grid by blocks.
+// Row and Column locate the first cell of the region, in reading order.
+type UnreachableRegionError struct {
+	Row       int
+	Column    int
+	CellCount int
+}
+
+func (e *UnreachableRegionError) Error() string {
+	return fmt.Sprintf("unreachable region at row #%v, column #%v: %v cells are separated from the rest of the grid",
+		e.Row, e.Column, e.CellCount)
+}
+
+func (e *UnreachableRegionError) Kind() string       { return "unreachable-region" }
+func (e *UnreachableRegionError) Severity() Severity { return SeverityWarning }
+func (e *UnreachableRegionError) Pos() Pos           { return NewPos(e.Column, e.Row) }
+
+// Report is the result of the validation of a grid.
+type Report struct {
+	// Issues are all the issues found, errors and warnings mixed.
+	Issues []Issue
+}
+
+// Errors returns the issues preventing the grid to be solved.
+func (r *Report) Errors() []Issue {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the issues which do not prevent the grid to be solved.
+func (r *Report) Warnings() []Issue {
+	return r.filter(SeverityWarning)
+}
+
+func (r *Report) filter(severity Severity) []Issue {
+	var issues []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity() == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// Err returns an error joining all the errors of this report, or nil if the report contains no error.
+func (r *Report) Err() error {
+	var errs []error
+	for _, issue := range r.Errors() {
+		errs = append(errs, issue)
+	}
+	return errors.Join(errs...)
+}
+
+// Validate validates the given cells and returns a report containing all the issues found.
+func Validate(cells [][]rune) *Report {
+	return ValidateWithMaxWordLength(cells, -1)
+}
+
+// ValidateWithMaxWordLength validates the given cells and returns a report containing all the issues found,
+// including the slots longer than the given maximum word length. A negative maximum word length disables the slot
+// length check.
+func ValidateWithMaxWordLength(cells [][]rune, maxWordLength int) *Report {
+	report := &Report{}
+	if len(cells) == 0 {
+		// Trivial case, empty Grid
+		return report
+	}
+	firstRowColumnCount := len(cells[0])
+	isRectangular := true
+	for rowIndex, row := range cells {
+		columnCount := len(row)
+		if firstRowColumnCount != columnCount {
+			report.Issues = append(report.Issues, &InconsistentRowLengthError{rowIndex, columnCount, firstRowColumnCount})
+			isRectangular = false
+		}
+		for columnIndex, value := range row {
+			if value != CellEmpty && value != CellBlock && !alphabet.Contains(value) {
+				report.Issues = append(report.Issues, &UnsupportedCharacterError{rowIndex, columnIndex, value})
+			}
+		}
+	}
+	if !isRectangular {
+		// Slots and regions are meaningless
+		return report
+	}
+	g := &Grid{cells}
+	report.Issues = append(report.Issues, g.uncheckedCellIssues()...)
+	if maxWordLength >= 0 {
+		report.Issues = append(report.Issues, g.slotTooLongIssues(maxWordLength)...)
+	}
+	report.Issues = append(report.Issues, g.unreachableRegionIssues()...)
+	return report
+}
+
+// uncheckedCellIssues returns the issues about the non-block cells which are not part of any slot.
+func (g *Grid) uncheckedCellIssues() []Issue {
+	checked := make(map[Pos]bool)
+	for _, slot := range g.Slots() {
+		for _, pos := range slot.Positions() {
+			checked[pos] = true
+		}
+	}
+	var issues []Issue
+	for rowIndex, row := range g.cells {
+		for columnIndex, cell := range row {
+			if cell != CellBlock && !checked[NewPos(columnIndex, rowIndex)] {
+				issues = append(issues, &UncheckedCellError{rowIndex, columnIndex})
+			}
+		}
+	}
+	return issues
+}
+
+// slotTooLongIssues returns the issues about the slots longer than the given maximum word length.
+func (g *Grid) slotTooLongIssues(maxWordLength int) []Issue {
+	var issues []Issue
+	for _, slot := range g.Slots() {
+		if slot.Length() > maxWordLength {
+			start := slot.Positions()[0]
+			issues = append(issues, &SlotTooLongError{start.Row(), start.Column(), slot.Length(), maxWordLength})
+		}
+	}
+	return issues
+}
+
+// unreachableRegionIssues returns the issues about the regions separated from the biggest region of the grid.
+func (g *Grid) unreachableRegionIssues() []Issue {
+	regions := g.Regions()
+	if len(regions) < 2 {
+		return nil
+	}
+	biggestRegionIndex := 0
+	for i, region := range regions {
+		if len(region) > len(regions[biggestRegionIndex]) {
+			biggestRegionIndex = i
+		}
+	}
+	var issues []Issue
+	for i, region := range regions {
+		if i != biggestRegionIndex {
+			first := region[0]
+			issues = append(issues, &UnreachableRegionError{first.Row(), first.Column(), len(region)})
+		}
+	}
+	return issues
+}
+
+// Regions returns the groups of non-block cells connected horizontally or vertically. Regions are ordered by their
+// first cell in reading order, and so are the positions within each region.
+func (g *Grid) Regions() [][]Pos {
+	visited := make(map[Pos]bool)
+	var regions [][]Pos
+	for rowIndex, row := range g.cells {
+		for columnIndex, cell := range row {
+			start := NewPos(columnIndex, rowIndex)
+			if cell == CellBlock || visited[start] {
+				continue
+			}
+			region := g.explore(start, visited)
+			slices.SortFunc(region, comparePos)
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+// explore returns the positions of the non-block cells reachable from the given start position, marking them as
+// visited.
+func (g *Grid) explore(start Pos, visited map[Pos]bool) []Pos {
+	var region []Pos
+	stack := []Pos{start}
+	visited[start] = true
+	for len(stack) > 0 {
+		pos := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		region = append(region, pos)
+		neighbours := []Pos{
+			NewPos(pos.Column(), pos.Row()-1),
+			NewPos(pos.Column(), pos.Row()+1),
+			NewPos(pos.Column()-1, pos.Row()),
+			NewPos(pos.Column()+1, pos.Row()),
+		}
+		for _, neighbour := range neighbours {
+			if g.contains(neighbour) && !visited[neighbour] &&
+				g.LetterAt(neighbour.Row(), neighbour.Column()) != CellBlock {
+				visited[neighbour] = true
+				stack = append(stack, neighbour)
+			}
+		}
+	}
+	return region
+}
+
+// contains returns true iff the given position is inside the grid.
+func (g *Grid) contains(pos Pos) bool {
+	return pos.Row() >= 0 && pos.Row() < g.RowCount() && pos.Column() >= 0 && pos.Column() < g.ColumnCount()
+}
+
+// comparePos compares positions in reading order.
+func comparePos(a, b Pos) int {
+	if a.Row() != b.Row() {
+		return a.Row() - b.Row()
+	}
+	return a.Column() - b.Column()
+}
@@ -0,0 +1,74 @@
+package grid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidate_AllErrors(t *testing.T) {
+	report := Validate([][]rune{
+		{'A', 'B', '@'},
+		{'.', '#'},
+		{'_', '.', '.'},
+	})
+
+	expectedIssues := []Issue{
+		&UnsupportedCharacterError{Row: 0, Column: 2, Value: '@'},
+		&InconsistentRowLengthError{Row: 1, ColumnCount: 2, ExpectedColumnCount: 3},
+		&UnsupportedCharacterError{Row: 2, Column: 0, Value: '_'},
+	}
+	assert.Equal(t, expectedIssues, report.Issues)
+	assert.EqualError(t, report.Err(), "invalid value at row #0, column #2: @\n"+
+		"inconsistent number of columns: Row #1 has 2 columns but row #0 has 3\n"+
+		"invalid value at row #2, column #0: _")
+}
+
+func TestValidate_UncheckedCell(t *testing.T) {
+	report := Validate([][]rune{
+		{'.', '.', '.'},
+		{'.', '#', '#'},
+		{'.', '#', 'A'},
+	})
+
+	expectedIssues := []Issue{
+		&UncheckedCellError{Row: 2, Column: 2},
+		&UnreachableRegionError{Row: 2, Column: 2, CellCount: 1},
+	}
+	assert.Equal(t, expectedIssues, report.Warnings())
+	assert.NoError(t, report.Err())
+}
+
+func TestValidate_SlotTooLong(t *testing.T) {
+	report := ValidateWithMaxWordLength([][]rune{
+		{'.', '.', '.'},
+		{'.', '#', '.'},
+	}, 2)
+
+	expectedIssues := []Issue{
+		&SlotTooLongError{Row: 0, Column: 0, Length: 3, MaxWordLength: 2},
+	}
+	assert.Equal(t, expectedIssues, report.Errors())
+}
+
+func TestValidate_UnreachableRegion(t *testing.T) {
+	report := Validate([][]rune{
+		{'.', '.', '#', '.', '.', '.'},
+		{'.', '.', '#', '.', '.', '.'},
+	})
+
+	assert.Equal(t, []Issue{&UnreachableRegionError{Row: 0, Column: 0, CellCount: 4}}, report.Warnings())
+}
+
+func TestRegions(t *testing.T) {
+	grid, _ := NewGrid([][]rune{
+		{'.', '#', '.'},
+		{'.', '#', '.'},
+		{'#', '.', '.'},
+	})
+
+	expectedRegions := [][]Pos{
+		{NewPos(0, 0), NewPos(0, 1)},
+		{NewPos(2, 0), NewPos(2, 1), NewPos(1, 2), NewPos(2, 2)},
+	}
+	assert.Equal(t, expectedRegions, grid.Regions())
+}
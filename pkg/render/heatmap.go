@@ -0,0 +1,158 @@
+package render
+
+import (
+	"crogo/pkg/crogo"
+	"crogo/pkg/grid"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// ANSI escape sequences used to colour the text heatmap.
+const (
+	ansiHeatDead  = "\x1b[1;37;41m" // bold white on red
+	ansiHeatTight = "\x1b[30;43m"   // black on yellow
+)
+
+// heatTightLetterCount is the maximal number of candidate letters of a cell considered as tight by the text heatmap.
+const heatTightLetterCount = 5
+
+// heatmapStyle is the additional style sheet of the SVG heatmap.
+const heatmapStyle = `.candidates { font-family: sans-serif; fill: black; text-anchor: middle; dominant-baseline: central }`
+
+// TextHeatmap renders the given candidates of the given grid as a boxed grid, like Text.
+//
+// The second line of each cell contains its candidate letters if there are 1 to 3 of them, or their number
+// otherwise. With colours, cells without candidate are shaded in red and tight cells in yellow.
+func TextHeatmap(g *grid.Grid, candidates crogo.Candidates, options TextOptions) string {
+	var builder strings.Builder
+	numbers := g.ClueNumbers()
+	columnCount := g.ColumnCount()
+	builder.WriteString(horizontalBorder('┌', '┬', '┐', columnCount))
+	for row := 0; row < g.RowCount(); row++ {
+		if row > 0 {
+			builder.WriteString(horizontalBorder('├', '┼', '┤', columnCount))
+		}
+		var numberLine, lettersLine strings.Builder
+		numberLine.WriteRune('│')
+		lettersLine.WriteRune('│')
+		for column := 0; column < columnCount; column++ {
+			inputLetter := g.LetterAt(row, column)
+			if inputLetter == grid.CellBlock {
+				numberLine.WriteString(strings.Repeat("█", textCellWidth))
+				lettersLine.WriteString(strings.Repeat("█", textCellWidth))
+			} else {
+				numberLine.WriteString(numberText(numbers, row, column))
+				lettersLine.WriteString(candidatesText(inputLetter, candidates.Letters[row][column], options))
+			}
+			numberLine.WriteRune('│')
+			lettersLine.WriteRune('│')
+		}
+		builder.WriteString(numberLine.String())
+		builder.WriteRune('\n')
+		builder.WriteString(lettersLine.String())
+		builder.WriteRune('\n')
+	}
+	builder.WriteString(horizontalBorder('└', '┴', '┘', columnCount))
+	return builder.String()
+}
+
+// candidatesText returns the second line of a non-block cell of the text heatmap, containing its candidates.
+func candidatesText(inputLetter rune, letters []rune, options TextOptions) string {
+	text := fmt.Sprintf("%-*s", textCellWidth, candidatesLabel(letters))
+	if len(letters) == 1 {
+		text = fmt.Sprintf(" %c ", letters[0])
+	}
+	if !options.Colors {
+		return text
+	}
+	switch {
+	case len(letters) == 0:
+		return ansiHeatDead + text + ansiReset
+	case inputLetter != grid.CellEmpty:
+		return ansiPrefilled + text + ansiReset
+	case len(letters) <= heatTightLetterCount:
+		return ansiHeatTight + text + ansiReset
+	default:
+		return text
+	}
+}
+
+// candidatesLabel returns the given candidate letters if there are 1 to 3 of them, or their number otherwise.
+func candidatesLabel(letters []rune) string {
+	if len(letters) > 0 && len(letters) <= textCellWidth {
+		return string(letters)
+	}
+	return fmt.Sprintf("%d", len(letters))
+}
+
+// SvgHeatmap renders the given candidates of the given grid as a standalone SVG document, like Svg.
+//
+// Cells are coloured from red, for cells without candidate letter, to green, for cells where all the letters remain
+// possible. They contain their candidate letters if there are 1 to 3 of them, or their number otherwise. The
+// tooltip of each cell lists its candidate letters and the number of words which remain possible in its tightest slot.
+func SvgHeatmap(g *grid.Grid, candidates crogo.Candidates, options SvgOptions) string {
+	cellSize := options.CellSize
+	if cellSize <= 0 {
+		cellSize = defaultSvgCellSize
+	}
+	// Half-pixel margin so that the outer borders are not clipped
+	width := float64(g.ColumnCount()*cellSize) + 1
+	height := float64(g.RowCount()*cellSize) + 1
+	numbers := g.ClueNumbers()
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&builder, "<style>\n%s\n%s\n</style>\n", svgStyle, heatmapStyle)
+	for row := range g.RowCount() {
+		for column := range g.ColumnCount() {
+			x, y := float64(column*cellSize)+0.5, float64(row*cellSize)+0.5
+			if g.LetterAt(row, column) == grid.CellBlock {
+				fmt.Fprintf(&builder, `<rect class="block" x="%g" y="%g" width="%d" height="%d"/>`+"\n",
+					x, y, cellSize, cellSize)
+				continue
+			}
+			letters := candidates.Letters[row][column]
+			fmt.Fprintf(&builder, `<rect class="cell" x="%g" y="%g" width="%d" height="%d" style="fill: %s">`,
+				x, y, cellSize, cellSize, heatColor(len(letters)))
+			fmt.Fprintf(&builder, "<title>%s</title></rect>\n",
+				html.EscapeString(heatmapTooltip(g, candidates, letters, grid.NewPos(column, row))))
+			if number, numbered := numbers[grid.NewPos(column, row)]; numbered {
+				fontSize := float64(cellSize*3) / 10
+				fmt.Fprintf(&builder, `<text class="number" x="%g" y="%g" font-size="%g">%d</text>`+"\n",
+					x+fontSize/3, y+fontSize, fontSize, number)
+			}
+			half := float64(cellSize) / 2
+			fmt.Fprintf(&builder, `<text class="candidates" x="%g" y="%g" font-size="%g">%s</text>`+"\n",
+				x+half, y+half, float64(cellSize*4)/10, html.EscapeString(candidatesLabel(letters)))
+		}
+	}
+	builder.WriteString("</svg>\n")
+	return builder.String()
+}
+
+// heatColor returns the CSS colour of a cell with the given number of candidate letters, from red to green. Cells
+// without candidate are darker.
+func heatColor(letterCount int) string {
+	if letterCount == 0 {
+		return "hsl(0, 80%, 50%)"
+	}
+	hue := 120 * min(letterCount, 26) / 26
+	return fmt.Sprintf("hsl(%d, 80%%, 75%%)", hue)
+}
+
+// heatmapTooltip returns the tooltip of the cell at the given position, with the given candidate letters.
+func heatmapTooltip(g *grid.Grid, candidates crogo.Candidates, letters []rune, pos grid.Pos) string {
+	tooltip := fmt.Sprintf("Letters: %s", string(letters))
+	wordCount := -1
+	for _, slot := range g.SlotsAt(pos) {
+		if slotWordCount := candidates.WordCounts[slot]; wordCount < 0 || slotWordCount < wordCount {
+			wordCount = slotWordCount
+		}
+	}
+	if wordCount >= 0 {
+		tooltip += fmt.Sprintf("\nWords: %d", wordCount)
+	}
+	return tooltip
+}
@@ -0,0 +1,69 @@
+package render
+
+import (
+	"crogo/pkg/crogo"
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func newTestingHeatmap(t *testing.T) (*grid.Grid, crogo.Candidates) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	candidates := crogo.Candidates{
+		Letters: [][][]rune{
+			{{'A'}, {'B', 'C'}, nil},
+			{{}, []rune("ABCDE"), []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")},
+		},
+		WordCounts: map[grid.Slot]int{
+			grid.NewAcrossSlot(0, 2, 0): 2,
+			grid.NewAcrossSlot(0, 3, 1): 0,
+			grid.NewDownSlot(0, 2, 0):   0,
+			grid.NewDownSlot(0, 2, 1):   10,
+		},
+	}
+	return g, candidates
+}
+
+func TestTextHeatmap(t *testing.T) {
+	g, candidates := newTestingHeatmap(t)
+
+	actual := TextHeatmap(g, candidates, TextOptions{})
+
+	expected := "" +
+		"┌───┬───┬───┐\n" +
+		"│1  │2  │███│\n" +
+		"│ A │BC │███│\n" +
+		"├───┼───┼───┤\n" +
+		"│3  │   │   │\n" +
+		"│0  │5  │26 │\n" +
+		"└───┴───┴───┘\n"
+	assert.Equal(t, expected, actual)
+}
+
+func TestTextHeatmap_Colors(t *testing.T) {
+	g, candidates := newTestingHeatmap(t)
+
+	actual := TextHeatmap(g, candidates, TextOptions{Colors: true})
+
+	assert.Contains(t, actual, "│"+ansiPrefilled+" A "+ansiReset+"│"+ansiHeatTight+"BC "+ansiReset+"│")
+	assert.Contains(t, actual, "│"+ansiHeatDead+"0  "+ansiReset+"│"+ansiHeatTight+"5  "+ansiReset+"│26 │")
+}
+
+func TestSvgHeatmap(t *testing.T) {
+	g, candidates := newTestingHeatmap(t)
+
+	actual := SvgHeatmap(g, candidates, SvgOptions{CellSize: 20})
+
+	assert.Equal(t, 5, strings.Count(actual, `class="cell"`))
+	assert.Equal(t, 1, strings.Count(actual, `class="block"`))
+	assert.Contains(t, actual, `<rect class="cell" x="20.5" y="0.5" width="20" height="20" style="fill: hsl(9, 80%, 75%)">`+
+		"<title>Letters: BC\nWords: 2</title></rect>\n")
+	assert.Contains(t, actual, `style="fill: hsl(0, 80%, 50%)"><title>Letters: `+"\nWords: 0</title>")
+	assert.Contains(t, actual, `<text class="candidates" x="50.5" y="30.5" font-size="8">26</text>`)
+}
@@ -0,0 +1,229 @@
+package render
+
+import (
+	"bytes"
+	"crogo/pkg/grid"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Page layout of the PDF rendering, in points. Pages are A4.
+const (
+	pdfPageWidth     = 595
+	pdfPageHeight    = 842
+	pdfMargin        = 50
+	pdfMaxCellSize   = 30
+	pdfTitleSize     = 14
+	pdfListFontSize  = 9
+	pdfListLeading   = 11
+	pdfListColumns   = 4
+	pdfGridListSpace = 24
+)
+
+// Resource names of the fonts of the PDF rendering.
+const (
+	pdfRegularFont = "F1"
+	pdfBoldFont    = "F2"
+)
+
+// Pdf renders the given grid as a printable PDF document.
+//
+// The first page contains the blank numbered grid - showing only the prefilled letters - and the skeleton of the clue
+// list, i.e. the numbers and enumerations of the Across and Down slots. If a solution is given, a second page contains
+// the answer key: The filled grid and the list of answers.
+//
+// The document only uses the standard Helvetica font, which is not embedded; Characters outside Windows-1252 are
+// replaced with '?'.
+func Pdf(w io.Writer, g *grid.Grid, solution [][]rune) error {
+	var document pdfDocument
+	pages := []*pdfPage{puzzlePage(g)}
+	if solution != nil {
+		pages = append(pages, answerPage(g, solution))
+	}
+	return document.write(w, pages)
+}
+
+// puzzlePage returns the page containing the blank grid and the clue list skeleton.
+func puzzlePage(g *grid.Grid) *pdfPage {
+	page := &pdfPage{}
+	page.text(pdfMargin, pdfMargin+pdfTitleSize, pdfBoldFont, pdfTitleSize, "Crossword")
+	gridBottom := page.grid(g, nil)
+	var lines []pdfLine
+	for _, direction := range []grid.Direction{grid.Across, grid.Down} {
+		lines = append(lines, pdfLine{direction.String(), true})
+		for _, slot := range g.NumberedSlots() {
+			if slot.Direction() == direction {
+				lines = append(lines, pdfLine{fmt.Sprintf("%d (%d) ____________", slot.Number(), slot.Length()), false})
+			}
+		}
+	}
+	page.list(lines, gridBottom+pdfGridListSpace)
+	return page
+}
+
+// answerPage returns the page containing the answer key.
+func answerPage(g *grid.Grid, solution [][]rune) *pdfPage {
+	page := &pdfPage{}
+	page.text(pdfMargin, pdfMargin+pdfTitleSize, pdfBoldFont, pdfTitleSize, "Answers")
+	gridBottom := page.grid(g, solution)
+	var lines []pdfLine
+	entries := g.Entries(solution)
+	for _, direction := range []grid.Direction{grid.Across, grid.Down} {
+		lines = append(lines, pdfLine{direction.String(), true})
+		for _, entry := range entries {
+			if entry.Direction() == direction {
+				lines = append(lines, pdfLine{fmt.Sprintf("%d %s", entry.Number(), entry.Word()), false})
+			}
+		}
+	}
+	page.list(lines, gridBottom+pdfGridListSpace)
+	return page
+}
+
+// pdfLine is a line of a list.
+type pdfLine struct {
+	text    string
+	heading bool
+}
+
+// pdfPage is a page being drawn. Coordinates of its methods are measured from the top-left corner of the page.
+type pdfPage struct {
+	content strings.Builder
+}
+
+// grid draws the given grid, filled with the given solution if not nil, below the page title. It returns the
+// coordinate of the bottom of the grid.
+func (p *pdfPage) grid(g *grid.Grid, solution [][]rune) float64 {
+	top := float64(pdfMargin + 2*pdfTitleSize)
+	cellSize := math.Min(pdfMaxCellSize, float64(pdfPageWidth-2*pdfMargin)/float64(g.ColumnCount()))
+	cellSize = math.Min(cellSize, float64(pdfPageHeight/2)/float64(g.RowCount()))
+	numbers := g.ClueNumbers()
+	fmt.Fprintf(&p.content, "0.5 w\n")
+	for row := range g.RowCount() {
+		for column := range g.ColumnCount() {
+			x, y := pdfMargin+float64(column)*cellSize, top+float64(row)*cellSize
+			inputLetter := g.LetterAt(row, column)
+			p.rect(x, y, cellSize, inputLetter == grid.CellBlock)
+			if inputLetter == grid.CellBlock {
+				continue
+			}
+			if number, numbered := numbers[grid.NewPos(column, row)]; numbered {
+				fontSize := cellSize * 0.3
+				p.text(x+fontSize/4, y+fontSize, pdfRegularFont, fontSize, fmt.Sprint(number))
+			}
+			letter := inputLetter
+			if letter == grid.CellEmpty {
+				letter = letterAt(solution, row, column)
+			}
+			if letter != grid.CellEmpty {
+				// Helvetica capitals are about 2/3 of the font size wide and 3/4 high
+				fontSize := cellSize * 0.6
+				p.text(x+cellSize/2-fontSize/3, y+cellSize/2+fontSize*3/8, pdfRegularFont, fontSize, string(letter))
+			}
+		}
+	}
+	return top + float64(g.RowCount())*cellSize
+}
+
+// list draws the given lines in columns, from the given top coordinate to the bottom margin. Leading is reduced if the
+// lines do not fit.
+func (p *pdfPage) list(lines []pdfLine, top float64) {
+	linesPerColumn := (len(lines) + pdfListColumns - 1) / pdfListColumns
+	leading := math.Min(pdfListLeading, (pdfPageHeight-pdfMargin-top)/float64(linesPerColumn))
+	fontSize := math.Min(pdfListFontSize, leading)
+	columnWidth := float64(pdfPageWidth-2*pdfMargin) / pdfListColumns
+	maxLinesPerColumn := max(1, int((pdfPageHeight-pdfMargin-top)/leading))
+	for i, line := range lines {
+		x := pdfMargin + float64(i/maxLinesPerColumn)*columnWidth
+		y := top + float64(i%maxLinesPerColumn+1)*leading
+		font := pdfRegularFont
+		if line.heading {
+			font = pdfBoldFont
+		}
+		p.text(x, y, font, fontSize, line.text)
+	}
+}
+
+// rect draws a square of the given size, filled if requested.
+func (p *pdfPage) rect(x, y, size float64, filled bool) {
+	operator := "S"
+	if filled {
+		operator = "B"
+	}
+	fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f re %s\n", x, pdfPageHeight-y-size, size, size, operator)
+}
+
+// text draws the given text with its baseline at the given coordinates.
+func (p *pdfPage) text(x, y float64, font string, fontSize float64, text string) {
+	fmt.Fprintf(&p.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, fontSize, x, pdfPageHeight-y,
+		pdfEscape(text))
+}
+
+// pdfEscape encodes the given text as the content of a PDF literal string, replacing unsupported characters with '?'.
+func pdfEscape(text string) string {
+	var escaped strings.Builder
+	for _, r := range text {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		if b == '\\' || b == '(' || b == ')' {
+			escaped.WriteByte('\\')
+		}
+		escaped.WriteByte(b)
+	}
+	return escaped.String()
+}
+
+// pdfDocument is a PDF document being written, i.e. a list of objects.
+type pdfDocument struct {
+	objects []string
+}
+
+// add adds the given object to the document and returns its number.
+func (d *pdfDocument) add(object string) int {
+	d.objects = append(d.objects, object)
+	return len(d.objects)
+}
+
+// write writes the document made of the given pages.
+func (d *pdfDocument) write(w io.Writer, pages []*pdfPage) error {
+	// Page tree is completed once its pages are added
+	pageTree := d.add("")
+	catalog := d.add(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pageTree))
+	regularFont := d.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	boldFont := d.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	var kids []string
+	for _, page := range pages {
+		content := page.content.String()
+		stream := d.add(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+		pageObject := d.add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+			pageTree, pdfPageWidth, pdfPageHeight, regularFont, boldFont, stream))
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+	}
+	d.objects[pageTree-1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+
+	var output bytes.Buffer
+	output.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(d.objects))
+	for i, object := range d.objects {
+		offsets[i] = output.Len()
+		fmt.Fprintf(&output, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := output.Len()
+	fmt.Fprintf(&output, "xref\n0 %d\n0000000000 65535 f \n", len(d.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&output, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&output, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(d.objects)+1, catalog,
+		xref)
+	if _, err := w.Write(output.Bytes()); err != nil {
+		return fmt.Errorf("cannot write pdf: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package render
+
+import (
+	"bytes"
+	"crogo/pkg/grid"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestPdf(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B', '#'},
+		{'C', 'D', 'E'},
+	}
+	var output bytes.Buffer
+
+	err = Pdf(&output, g, solution)
+
+	require.NoError(t, err)
+	content := output.String()
+	assert.Regexp(t, `^%PDF-1\.4\n`, content)
+	assert.Regexp(t, `%%EOF\n$`, content)
+	assert.Contains(t, content, "/Count 2")
+	assertValidXref(t, output.Bytes())
+	// Clue list skeleton
+	assert.Contains(t, content, `(Across) Tj`)
+	assert.Contains(t, content, `(3 \(3\) ____________) Tj`)
+	assert.Contains(t, content, `(2 \(2\) ____________) Tj`)
+	// Answer key
+	assert.Contains(t, content, `(Answers) Tj`)
+	assert.Contains(t, content, `(3 CDE) Tj`)
+	assert.Contains(t, content, `(2 BD) Tj`)
+}
+
+func TestPdf_Unsolved(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'.', '.'},
+		{'.', '.'},
+	})
+	require.NoError(t, err)
+	var output bytes.Buffer
+
+	err = Pdf(&output, g, nil)
+
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "/Count 1")
+	assert.NotContains(t, output.String(), "Answers")
+	assertValidXref(t, output.Bytes())
+}
+
+func TestPdfEscape(t *testing.T) {
+	assert.Equal(t, "a\\(b\\)\\\\ \xe9 ?", pdfEscape(`a(b)\ é 🐊`))
+}
+
+// assertValidXref verifies that the cross-reference table of the given PDF document points to its objects.
+func assertValidXref(t *testing.T, document []byte) {
+	startXref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(document)
+	require.NotNil(t, startXref)
+	xrefOffset, err := strconv.Atoi(string(startXref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(document[xrefOffset:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(document[xrefOffset:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(document[offset:], fmt.Appendf(nil, "%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+}
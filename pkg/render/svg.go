@@ -0,0 +1,79 @@
+package render
+
+import (
+	"crogo/pkg/grid"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// defaultSvgCellSize is the default size of the cells of the SVG rendering, in pixels.
+const defaultSvgCellSize = 40
+
+// svgStyle is the style sheet of the SVG rendering. Elements are classed so that it can be overridden by web pages.
+const svgStyle = `.cell { fill: white; stroke: black; stroke-width: 1 }
+.block { fill: black; stroke: black; stroke-width: 1 }
+.prefilled { fill: #dde6f7 }
+.number { font-family: sans-serif; fill: black }
+.letter { font-family: sans-serif; fill: black; text-anchor: middle; dominant-baseline: central }`
+
+// SvgOptions are the options of the SVG rendering.
+type SvgOptions struct {
+	// CellSize is the size of a cell in pixels; 0 means the default size.
+	CellSize int
+	// HighlightPrefilled shades the cells whose letter is given by the input grid.
+	HighlightPrefilled bool
+}
+
+// Svg renders the given solution of the given grid as a standalone SVG document.
+//
+// Blocks are filled in black and clue numbers are written in the top-left corner of the cells. A nil solution renders
+// the grid itself, i.e. the puzzle version with only the prefilled letters.
+func Svg(g *grid.Grid, solution [][]rune, options SvgOptions) string {
+	cellSize := options.CellSize
+	if cellSize <= 0 {
+		cellSize = defaultSvgCellSize
+	}
+	// Half-pixel margin so that the outer borders are not clipped
+	width := float64(g.ColumnCount()*cellSize) + 1
+	height := float64(g.RowCount()*cellSize) + 1
+	numbers := g.ClueNumbers()
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&builder, "<style>\n%s\n</style>\n", svgStyle)
+	for row := range g.RowCount() {
+		for column := range g.ColumnCount() {
+			x, y := float64(column*cellSize)+0.5, float64(row*cellSize)+0.5
+			inputLetter := g.LetterAt(row, column)
+			class := "cell"
+			if inputLetter == grid.CellBlock {
+				class = "block"
+			} else if inputLetter != grid.CellEmpty && options.HighlightPrefilled {
+				class = "cell prefilled"
+			}
+			fmt.Fprintf(&builder, `<rect class="%s" x="%g" y="%g" width="%d" height="%d"/>`+"\n",
+				class, x, y, cellSize, cellSize)
+			if inputLetter == grid.CellBlock {
+				continue
+			}
+			if number, numbered := numbers[grid.NewPos(column, row)]; numbered {
+				fontSize := float64(cellSize*3) / 10
+				fmt.Fprintf(&builder, `<text class="number" x="%g" y="%g" font-size="%g">%d</text>`+"\n",
+					x+fontSize/3, y+fontSize, fontSize, number)
+			}
+			letter := inputLetter
+			if letter == grid.CellEmpty {
+				letter = letterAt(solution, row, column)
+			}
+			if letter != grid.CellEmpty {
+				half := float64(cellSize) / 2
+				fmt.Fprintf(&builder, `<text class="letter" x="%g" y="%g" font-size="%g">%s</text>`+"\n",
+					x+half, y+half, float64(cellSize*6)/10, html.EscapeString(string(letter)))
+			}
+		}
+	}
+	builder.WriteString("</svg>\n")
+	return builder.String()
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSvg(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.'},
+		{'#', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B'},
+		{'#', 'C'},
+	}
+
+	actual := Svg(g, solution, SvgOptions{CellSize: 20})
+
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" width="41" height="41" viewBox="0 0 41 41">
+<style>
+` + svgStyle + `
+</style>
+<rect class="cell" x="0.5" y="0.5" width="20" height="20"/>
+<text class="number" x="2.5" y="6.5" font-size="6">1</text>
+<text class="letter" x="10.5" y="10.5" font-size="12">A</text>
+<rect class="cell" x="20.5" y="0.5" width="20" height="20"/>
+<text class="number" x="22.5" y="6.5" font-size="6">2</text>
+<text class="letter" x="30.5" y="10.5" font-size="12">B</text>
+<rect class="block" x="0.5" y="20.5" width="20" height="20"/>
+<rect class="cell" x="20.5" y="20.5" width="20" height="20"/>
+<text class="letter" x="30.5" y="30.5" font-size="12">C</text>
+</svg>
+`
+	assert.Equal(t, expected, actual)
+}
+
+func TestSvg_Unsolved(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.'},
+		{'.', '.'},
+	})
+	require.NoError(t, err)
+
+	actual := Svg(g, nil, SvgOptions{HighlightPrefilled: true})
+
+	assert.Equal(t, 1, strings.Count(actual, `class="letter"`))
+	assert.Contains(t, actual, `<rect class="cell prefilled" x="0.5" y="0.5" width="40" height="40"/>`)
+	assert.Contains(t, actual, `<text class="letter" x="20.5" y="20.5" font-size="24">A</text>`)
+	assert.Contains(t, actual, `<text class="number" x="4.5" y="12.5" font-size="12">1</text>`)
+	assert.Equal(t, 3, strings.Count(actual, `class="number"`))
+}
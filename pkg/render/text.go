@@ -0,0 +1,114 @@
+// Package render draws crossword grids and their solutions for humans.
+package render
+
+import (
+	"crogo/pkg/grid"
+	"fmt"
+	"strings"
+)
+
+// ANSI escape sequences used to colour the text rendering.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiPrefilled = "\x1b[1;34m" // bold blue
+	ansiFilled    = "\x1b[32m"   // green
+)
+
+// textCellWidth is the number of characters inside a cell of the text rendering.
+const textCellWidth = 3
+
+// TextOptions are the options of the text rendering.
+type TextOptions struct {
+	// Colors enables ANSI colours, distinguishing the letters of the input grid from the letters found by the solver.
+	Colors bool
+}
+
+// Text renders the given solution of the given grid as a boxed grid drawn with Unicode box-drawing characters.
+//
+// Each cell spans two lines: The first one contains the clue number, if any, in the top-left corner and the second one
+// contains the letter. Blocks are shaded. A nil solution renders the grid itself, i.e. the unsolved puzzle.
+func Text(g *grid.Grid, solution [][]rune, options TextOptions) string {
+	var builder strings.Builder
+	numbers := g.ClueNumbers()
+	columnCount := g.ColumnCount()
+	builder.WriteString(horizontalBorder('┌', '┬', '┐', columnCount))
+	for row := 0; row < g.RowCount(); row++ {
+		if row > 0 {
+			builder.WriteString(horizontalBorder('├', '┼', '┤', columnCount))
+		}
+		var numberLine, letterLine strings.Builder
+		numberLine.WriteRune('│')
+		letterLine.WriteRune('│')
+		for column := 0; column < columnCount; column++ {
+			inputLetter := g.LetterAt(row, column)
+			if inputLetter == grid.CellBlock {
+				numberLine.WriteString(strings.Repeat("█", textCellWidth))
+				letterLine.WriteString(strings.Repeat("█", textCellWidth))
+			} else {
+				numberLine.WriteString(numberText(numbers, row, column))
+				letterLine.WriteString(letterText(inputLetter, letterAt(solution, row, column), options))
+			}
+			numberLine.WriteRune('│')
+			letterLine.WriteRune('│')
+		}
+		builder.WriteString(numberLine.String())
+		builder.WriteRune('\n')
+		builder.WriteString(letterLine.String())
+		builder.WriteRune('\n')
+	}
+	builder.WriteString(horizontalBorder('└', '┴', '┘', columnCount))
+	return builder.String()
+}
+
+// horizontalBorder returns a horizontal border line made of the given corner and junction characters.
+func horizontalBorder(left, middle, right rune, columnCount int) string {
+	if columnCount == 0 {
+		return ""
+	}
+	cellBorder := strings.Repeat("─", textCellWidth)
+	var builder strings.Builder
+	builder.WriteRune(left)
+	for column := 0; column < columnCount; column++ {
+		if column > 0 {
+			builder.WriteRune(middle)
+		}
+		builder.WriteString(cellBorder)
+	}
+	builder.WriteRune(right)
+	builder.WriteRune('\n')
+	return builder.String()
+}
+
+// numberText returns the first line of the cell at the given position, containing the clue number, if any.
+func numberText(numbers map[grid.Pos]int, row, column int) string {
+	number, numbered := numbers[grid.NewPos(column, row)]
+	if !numbered {
+		return strings.Repeat(" ", textCellWidth)
+	}
+	return fmt.Sprintf("%-*d", textCellWidth, number)
+}
+
+// letterText returns the second line of a non-block cell, containing the letter, if any.
+func letterText(inputLetter, solutionLetter rune, options TextOptions) string {
+	letter := inputLetter
+	color := ansiPrefilled
+	if letter == grid.CellEmpty {
+		letter = solutionLetter
+		color = ansiFilled
+	}
+	if letter == grid.CellEmpty {
+		return strings.Repeat(" ", textCellWidth)
+	}
+	if options.Colors {
+		return fmt.Sprintf(" %s%c%s ", color, letter, ansiReset)
+	}
+	return fmt.Sprintf(" %c ", letter)
+}
+
+// letterAt returns the letter of the given solution at the given position, or grid.CellEmpty if there is no solution.
+func letterAt(solution [][]rune, row, column int) rune {
+	if solution == nil {
+		return grid.CellEmpty
+	}
+	return solution[row][column]
+}
@@ -0,0 +1,66 @@
+package render
+
+import (
+	"crogo/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.', '#'},
+		{'.', '.', '.'},
+	})
+	require.NoError(t, err)
+	solution := [][]rune{
+		{'A', 'B', '#'},
+		{'C', 'D', 'E'},
+	}
+
+	actual := Text(g, solution, TextOptions{})
+
+	expected := "" +
+		"┌───┬───┬───┐\n" +
+		"│1  │2  │███│\n" +
+		"│ A │ B │███│\n" +
+		"├───┼───┼───┤\n" +
+		"│3  │   │   │\n" +
+		"│ C │ D │ E │\n" +
+		"└───┴───┴───┘\n"
+	assert.Equal(t, expected, actual)
+}
+
+func TestText_Unsolved(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{
+		{'A', '.'},
+		{'.', '.'},
+	})
+	require.NoError(t, err)
+
+	actual := Text(g, nil, TextOptions{})
+
+	expected := "" +
+		"┌───┬───┐\n" +
+		"│1  │2  │\n" +
+		"│ A │   │\n" +
+		"├───┼───┤\n" +
+		"│3  │   │\n" +
+		"│   │   │\n" +
+		"└───┴───┘\n"
+	assert.Equal(t, expected, actual)
+}
+
+func TestText_Colors(t *testing.T) {
+	g, err := grid.NewGrid([][]rune{{'A', '.'}})
+	require.NoError(t, err)
+
+	actual := Text(g, [][]rune{{'A', 'B'}}, TextOptions{Colors: true})
+
+	expected := "" +
+		"┌───┬───┐\n" +
+		"│1  │   │\n" +
+		"│ \x1b[1;34mA\x1b[0m │ \x1b[32mB\x1b[0m │\n" +
+		"└───┴───┘\n"
+	assert.Equal(t, expected, actual)
+}
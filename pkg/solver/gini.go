@@ -1,18 +1,27 @@
 package solver
 
 import (
+	"context"
 	"github.com/go-air/gini"
 	"github.com/go-air/gini/z"
 	"iter"
+	"time"
 )
 
+// giniPollInterval is the interval at which an interruptible search checks whether the solver has found a result.
+const giniPollInterval = time.Millisecond
+
 type giniSolver struct {
 	*BaseConfigurer
 	backend           *gini.Gini
 	relevantVariables []z.Var
+	// scopes are the activation literals of the scopes opened by Push: Clauses added in a scope are activated by its
+	// literal, which is assumed by each search.
+	scopes []z.Lit
 }
 
-// NewGiniSolver creates a new instance of a spi.ConfigurableSolver based on Gini.
+// NewGiniSolver creates a new instance of a spi.ConfigurableSolver based on Gini. Returned solver is also an
+// InterruptibleSolver and an IncrementalSolver.
 func NewGiniSolver() ConfigurableSolver {
 	backend := gini.New()
 	baseConfigurer := BaseConfigurer{}
@@ -21,11 +30,32 @@ func NewGiniSolver() ConfigurableSolver {
 	return &solverConfigurer
 }
 
+func (g *giniSolver) AllocateVariables(variableCount uint) {
+	// Variables are allocated implicitly when added, but activation literals must not clash with problem variables
+	for g.backend.MaxVar() < z.Var(variableCount) {
+		g.backend.Lit()
+	}
+}
+
 func (g *giniSolver) AddClause(spiLiterals []Literal) {
 	for _, spiLiteral := range spiLiterals {
 		g.backend.Add(z.Dimacs2Lit(int(spiLiteral)))
 	}
-	g.backend.Add(0)
+	g.endClause(len(spiLiterals) == 0)
+}
+
+// endClause terminates the clause being added, activating it by the literal of the current scope, if any.
+func (g *giniSolver) endClause(isEmpty bool) {
+	switch {
+	case len(g.scopes) == 0:
+		g.backend.Add(0)
+	case isEmpty:
+		// Gini cannot activate the empty clause, disable the scope instead
+		g.backend.Add(g.scopes[len(g.scopes)-1].Not())
+		g.backend.Add(0)
+	default:
+		g.backend.ActivateWith(g.scopes[len(g.scopes)-1])
+	}
 }
 
 func (g *giniSolver) SetRelevantVariables(variables []Variable) {
@@ -36,32 +66,118 @@ func (g *giniSolver) SetRelevantVariables(variables []Variable) {
 }
 
 func (g *giniSolver) Solutions() iter.Seq[Model] {
+	return g.SolutionsWithContext(context.Background())
+}
+
+func (g *giniSolver) SolutionsWithContext(ctx context.Context) iter.Seq[Model] {
+	return g.solutions(ctx, nil)
+}
+
+func (g *giniSolver) SolveUnder(ctx context.Context, assumptions []Literal) (Model, error) {
+	switch g.solveUnder(ctx, giniLitsFrom(assumptions)) {
+	case 1:
+		return g.model(), nil
+	case -1:
+		return nil, nil
+	default:
+		return nil, ctx.Err()
+	}
+}
+
+func (g *giniSolver) SolutionsUnder(ctx context.Context, assumptions []Literal) iter.Seq[Model] {
+	return func(yield func(Model) bool) {
+		g.Push()
+		defer g.Pop()
+		g.solutions(ctx, giniLitsFrom(assumptions))(yield)
+	}
+}
+
+func (g *giniSolver) Push() {
+	g.scopes = append(g.scopes, g.backend.ActivationLit())
+}
+
+func (g *giniSolver) Pop() {
+	scope := g.scopes[len(g.scopes)-1]
+	g.scopes = g.scopes[:len(g.scopes)-1]
+	g.backend.Deactivate(scope)
+}
+
+// solutions returns an iterator on the solutions satisfying the given assumptions.
+func (g *giniSolver) solutions(ctx context.Context, assumptions []z.Lit) iter.Seq[Model] {
 	return func(yield func(Model) bool) {
 		for {
-			if res := g.backend.Solve(); res != 1 {
+			if res := g.solveUnder(ctx, assumptions); res != 1 {
 				break
 			}
-			adaptedModel := make([]bool, len(g.relevantVariables))
-			for i, variable := range g.relevantVariables {
-				adaptedModel[i] = g.backend.Value(variable.Pos())
-			}
+			adaptedModel := g.model()
 			if keepGoing := yield(adaptedModel); !keepGoing {
 				break
 			}
 			for i, isPos := range adaptedModel {
-				g.backend.Add(boolToGiniLit(i+1, isPos).Not())
+				g.backend.Add(boolToGiniLit(g.relevantVariables[i], isPos).Not())
 			}
-			g.backend.Add(0)
+			g.endClause(len(adaptedModel) == 0)
 		}
 	}
 }
 
-func boolToGiniLit(variable int, isPos bool) z.Lit {
+// solveUnder solves the problem under the given assumptions and the activation literals of the open scopes, see solve.
+func (g *giniSolver) solveUnder(ctx context.Context, assumptions []z.Lit) int {
+	if ctx.Err() != nil {
+		return 0
+	}
+	// Assumptions are consumed by each search
+	g.backend.Assume(g.scopes...)
+	g.backend.Assume(assumptions...)
+	return g.solve(ctx)
+}
+
+// model returns the state of the relevant variables in the last solution found.
+func (g *giniSolver) model() Model {
+	model := make(Model, len(g.relevantVariables))
+	for i, variable := range g.relevantVariables {
+		model[i] = g.backend.Value(variable.Pos())
+	}
+	return model
+}
+
+// solve solves the problem, stopping the search if the given context is done. It returns 1 if sat, -1 if unsat and 0
+// if interrupted before a result is found.
+func (g *giniSolver) solve(ctx context.Context) int {
+	if ctx.Done() == nil {
+		// Not interruptible, no need to solve in background
+		return g.backend.Solve()
+	}
+	solve := g.backend.GoSolve()
+	ticker := time.NewTicker(giniPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return solve.Stop()
+		case <-ticker.C:
+			if res, done := solve.Test(); done {
+				return res
+			}
+		}
+	}
+}
+
+// giniLitsFrom returns the Gini literals corresponding to the given literals.
+func giniLitsFrom(spiLiterals []Literal) []z.Lit {
+	literals := make([]z.Lit, len(spiLiterals))
+	for i, spiLiteral := range spiLiterals {
+		literals[i] = z.Dimacs2Lit(int(spiLiteral))
+	}
+	return literals
+}
+
+func boolToGiniLit(variable z.Var, isPos bool) z.Lit {
 	var lit z.Lit
 	if isPos {
-		lit = z.Var(variable).Pos()
+		lit = variable.Pos()
 	} else {
-		lit = z.Var(variable).Neg()
+		lit = variable.Neg()
 	}
 	return lit
 }
@@ -1,7 +1,10 @@
 package solver
 
 import (
+	"context"
+	"fmt"
 	"github.com/booleworks/logicng-go/formula"
+	"github.com/booleworks/logicng-go/handler"
 	"github.com/booleworks/logicng-go/sat"
 	"iter"
 	"slices"
@@ -11,9 +14,12 @@ type logicNgSolver struct {
 	*BaseConfigurer
 	satSolver         *sat.Solver
 	relevantVariables []formula.Variable
+	// scopes are the solver states saved by Push, to be restored by Pop.
+	scopes []*sat.SolverState
 }
 
-// NewLogicNgSolver creates a new instance of a spi.ConfigurableSolver based on LogicNg.
+// NewLogicNgSolver creates a new instance of a spi.ConfigurableSolver based on LogicNg. Returned solver is also an
+// InterruptibleSolver and an IncrementalSolver.
 func NewLogicNgSolver() ConfigurableSolver {
 	formulaFactory := formula.NewFactory()
 	satSolver := sat.NewSolver(formulaFactory)
@@ -54,29 +60,108 @@ func (l *logicNgSolver) AddExactlyOne(spiLiterals []Literal) {
 }
 
 func (l *logicNgSolver) Solutions() iter.Seq[Model] {
+	return l.SolutionsWithContext(context.Background())
+}
+
+func (l *logicNgSolver) SolutionsWithContext(ctx context.Context) iter.Seq[Model] {
+	return l.solutions(ctx, nil)
+}
+
+func (l *logicNgSolver) SolveUnder(ctx context.Context, assumptions []Literal) (Model, error) {
+	model, ok := l.solve(ctx, l.logicNgLitsFrom(assumptions))
+	if !ok {
+		return nil, ctx.Err()
+	}
+	return model, nil
+}
+
+func (l *logicNgSolver) SolutionsUnder(ctx context.Context, assumptions []Literal) iter.Seq[Model] {
+	return func(yield func(Model) bool) {
+		l.Push()
+		defer l.Pop()
+		l.solutions(ctx, l.logicNgLitsFrom(assumptions))(yield)
+	}
+}
+
+func (l *logicNgSolver) Push() {
+	l.scopes = append(l.scopes, l.satSolver.SaveState())
+}
+
+// Pop restores the solver state saved by the matching Push. It panics if LogicNg rejects the state, which only happens
+// if it has been invalidated by loading an older state: Since scopes are closed in reverse order of opening, it does not
+// happen.
+func (l *logicNgSolver) Pop() {
+	state := l.scopes[len(l.scopes)-1]
+	l.scopes = l.scopes[:len(l.scopes)-1]
+	if err := l.satSolver.LoadState(state); err != nil {
+		panic(fmt.Sprintf("cannot restore solver state: %v", err))
+	}
+}
+
+// solutions returns an iterator on the solutions satisfying the given assumptions.
+func (l *logicNgSolver) solutions(ctx context.Context, assumptions []formula.Literal) iter.Seq[Model] {
 	return func(yield func(Model) bool) {
 		for {
-			result := l.satSolver.Call(sat.WithModel(l.relevantVariables))
-			if !result.OK() || !result.Sat() {
+			model, ok := l.solve(ctx, assumptions)
+			if !ok || model == nil {
 				break
 			}
-
-			model := result.Model().Literals
-			adaptedModel := make([]bool, len(model))
-			for i, lit := range model {
-				adaptedModel[i] = lit.IsPos()
-			}
-			if keepGoing := yield(adaptedModel); !keepGoing {
+			if keepGoing := yield(model); !keepGoing {
 				break
 			}
 
 			differentModel := make([]formula.Literal, len(model))
 			factory := l.satSolver.Factory()
-			for i, lit := range model {
-				differentModel[i] = lit.Negate(factory)
+			for i, isPos := range model {
+				if isPos {
+					differentModel[i] = l.relevantVariables[i].Negate(factory)
+				} else {
+					differentModel[i] = l.relevantVariables[i].AsLiteral()
+				}
 			}
 			differentModelClause := factory.Clause(differentModel...)
 			l.satSolver.Add(differentModelClause)
 		}
 	}
 }
+
+// solve searches for a solution satisfying the given assumptions. It returns the state of the relevant variables in
+// the solution found, or nil if there is none. The returned boolean is false if the search has been interrupted.
+func (l *logicNgSolver) solve(ctx context.Context, assumptions []formula.Literal) (Model, bool) {
+	if ctx.Err() != nil {
+		return nil, false
+	}
+	contextHandler := &contextHandler{ctx: ctx}
+	params := sat.WithModel(l.relevantVariables).Literal(assumptions...).Handler(contextHandler)
+	result := l.satSolver.Call(params)
+	if !result.OK() {
+		return nil, false
+	}
+	if !result.Sat() {
+		return nil, true
+	}
+	literals := result.Model().Literals
+	model := make(Model, len(literals))
+	for i, lit := range literals {
+		model[i] = lit.IsPos()
+	}
+	return model, true
+}
+
+// contextHandler is a sat.Handler aborting the search when its context is done.
+type contextHandler struct {
+	handler.Computation
+	ctx context.Context
+}
+
+func (h *contextHandler) DetectedConflict() bool {
+	if h.ctx.Err() != nil {
+		h.SetAborted(true)
+		return false
+	}
+	return true
+}
+
+func (h *contextHandler) FinishedSolving() {
+	// Do nothing.
+}
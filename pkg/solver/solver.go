@@ -1,6 +1,8 @@
 package solver
 
 import (
+	"context"
+	"fmt"
 	"iter"
 	"strconv"
 )
@@ -44,6 +46,39 @@ type Solver interface {
 	Solutions() iter.Seq[Model]
 }
 
+// InterruptibleSolver defines a Solver whose search can be interrupted.
+type InterruptibleSolver interface {
+	Solver
+	// SolutionsWithContext returns an iterator on the solutions, which stops as soon as the given context is done,
+	// including in the middle of the search for the next solution.
+	SolutionsWithContext(ctx context.Context) iter.Seq[Model]
+}
+
+// IncrementalSolver defines a Solver which can be queried several times under different assumptions, without encoding
+// the problem again.
+//
+// Clauses may be added in scopes, delimited by Push and Pop calls: Pop discards the clauses added since the matching
+// Push. Variables of the clauses added in scopes must have been allocated beforehand, see
+// Configurer.AllocateVariables.
+//
+// Search stops as soon as the given context is done. Implementations which are not InterruptibleSolver only check the
+// context between two searches.
+type IncrementalSolver interface {
+	Solver
+	// SolveUnder searches for a solution satisfying the given assumptions. It returns the solution found, or nil if
+	// there is none. It returns the context error if the search has been interrupted.
+	SolveUnder(ctx context.Context, assumptions []Literal) (Model, error)
+	// SolutionsUnder returns an iterator on the solutions satisfying the given assumptions. Unlike the ones added by
+	// Solutions, the clauses blocking the solutions found during the iteration are discarded once the iteration is
+	// over, so that they do not affect the next calls.
+	SolutionsUnder(ctx context.Context, assumptions []Literal) iter.Seq[Model]
+	// Push opens a new scope.
+	Push()
+	// Pop closes the last scope opened by Push, discarding the clauses added since then. It panics if there is no open
+	// scope, or if the backend fails to restore its state from before the scope.
+	Pop()
+}
+
 // Configurer defines a solver configurer.
 type Configurer interface {
 	// AllocateVariables gives a hint about the number of variables.
@@ -78,6 +113,30 @@ type ConfigurableSolver interface {
 	Configurer
 }
 
+// Factory creates new instances of a solver.
+type Factory func() ConfigurableSolver
+
+// NewFactory returns the factory of the solver of the given name, "logicng" or "gini".
+func NewFactory(name string) (Factory, error) {
+	switch name {
+	case "logicng":
+		return NewLogicNgSolver, nil
+	case "gini":
+		return NewGiniSolver, nil
+	default:
+		return nil, fmt.Errorf("unknown solver: %s", name)
+	}
+}
+
+// NewSolver returns a new instance of the solver of the given name, "logicng" or "gini".
+func NewSolver(name string) (ConfigurableSolver, error) {
+	factory, err := NewFactory(name)
+	if err != nil {
+		return nil, err
+	}
+	return factory(), nil
+}
+
 // BaseConfigurer provides default implementations for all the functions of the Configurer interface but for the
 // Configurer.AddClause function. These default implementations may be overridden for better performances.
 type BaseConfigurer struct {
@@ -1,7 +1,9 @@
 package solver
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -38,3 +40,132 @@ func TestAddAnd(t *testing.T) {
 	solverConfigurer.AddAnd(42, []Literal{-1, 6, -7})
 	assert.Equal(t, [][]Literal{{-42, -1}, {-42, 6}, {-42, -7}, {1, -6, 7, 42}}, solverConfigurer.clauses)
 }
+
+func TestNewSolver(t *testing.T) {
+	for _, name := range []string{"logicng", "gini"} {
+		s, err := NewSolver(name)
+		assert.NoError(t, err)
+		assert.NotNil(t, s)
+	}
+	_, err := NewSolver("minisat")
+	assert.EqualError(t, err, "unknown solver: minisat")
+}
+
+func TestSolutions_RelevantVariables(t *testing.T) {
+	for _, name := range []string{"logicng", "gini"} {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSolver(name)
+			require.NoError(t, err)
+			s.AllocateVariables(3)
+			s.SetRelevantVariables([]Variable{2, 3})
+			s.AddExactlyOne([]Literal{2, 3})
+
+			var models []Model
+			for model := range s.Solutions() {
+				models = append(models, model)
+				if len(models) > 2 {
+					// Solutions are not blocked
+					break
+				}
+			}
+
+			assert.ElementsMatch(t, []Model{{true, false}, {false, true}}, models)
+		})
+	}
+}
+
+func TestSolutionsUnder(t *testing.T) {
+	for _, name := range []string{"logicng", "gini"} {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSolver(name)
+			require.NoError(t, err)
+			s.SetRelevantVariables([]Variable{1, 2})
+			s.AddClause([]Literal{1, 2})
+			incrementalSolver := s.(IncrementalSolver)
+
+			var models []Model
+			for model := range incrementalSolver.SolutionsUnder(context.Background(), []Literal{-1}) {
+				models = append(models, model)
+			}
+			assert.Equal(t, []Model{{false, true}}, models)
+
+			// Blocking clauses of previous call are discarded
+			models = nil
+			for model := range incrementalSolver.SolutionsUnder(context.Background(), nil) {
+				models = append(models, model)
+			}
+			assert.ElementsMatch(t, []Model{{false, true}, {true, false}, {true, true}}, models)
+
+			for range incrementalSolver.SolutionsUnder(context.Background(), []Literal{-1, -2}) {
+				assert.Fail(t, "unexpected solution")
+			}
+		})
+	}
+}
+
+func TestSolveUnder(t *testing.T) {
+	for _, name := range []string{"logicng", "gini"} {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSolver(name)
+			require.NoError(t, err)
+			s.SetRelevantVariables([]Variable{1, 2})
+			s.AddClause([]Literal{1, 2})
+			incrementalSolver := s.(IncrementalSolver)
+
+			model, err := incrementalSolver.SolveUnder(context.Background(), []Literal{-2})
+			require.NoError(t, err)
+			assert.Equal(t, Model{true, false}, model)
+
+			model, err = incrementalSolver.SolveUnder(context.Background(), []Literal{-1, -2})
+			require.NoError(t, err)
+			assert.Nil(t, model)
+		})
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	for _, name := range []string{"logicng", "gini"} {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSolver(name)
+			require.NoError(t, err)
+			s.AllocateVariables(2)
+			s.SetRelevantVariables([]Variable{1, 2})
+			s.AddClause([]Literal{1, 2})
+			incrementalSolver := s.(IncrementalSolver)
+
+			incrementalSolver.Push()
+			s.AddClause([]Literal{-1})
+			incrementalSolver.Push()
+			s.AddClause([]Literal{-2})
+			model, err := incrementalSolver.SolveUnder(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Nil(t, model)
+
+			incrementalSolver.Pop()
+			model, err = incrementalSolver.SolveUnder(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, Model{false, true}, model)
+
+			incrementalSolver.Pop()
+			model, err = incrementalSolver.SolveUnder(context.Background(), []Literal{-2})
+			require.NoError(t, err)
+			assert.Equal(t, Model{true, false}, model)
+		})
+	}
+}
+
+func TestSolveUnder_Cancelled(t *testing.T) {
+	for _, name := range []string{"logicng", "gini"} {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewSolver(name)
+			require.NoError(t, err)
+			s.AddClause([]Literal{1, 2})
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			model, err := s.(IncrementalSolver).SolveUnder(ctx, nil)
+			assert.Nil(t, model)
+			assert.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}